@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/bianxm/godle/adversary"
+	"github.com/bianxm/godle/wordle"
+)
+
+// handleStartAbsurd switches to absurd mode, where there's no word to find
+// until the adversary runs out of ways to dodge.
+func (m *model) handleStartAbsurd() {
+	m.mode = modeAbsurd
+	m.daily = false
+	m.savePath = ""
+	m.gameOver = false
+	m.handleResetWordleState()
+	m.handleResetActiveGuess()
+	m.handleResetStatus()
+}
+
+// handleResetAbsurdState starts a game against a fresh adversary.
+func (m *model) handleResetAbsurdState() {
+	m.adversary = adversary.New(m.dict.Answers())
+	ws := wordle.NewAnswererState(m.adversary, m.rules)
+	m.handleNewState(&ws)
+}
+
+// handleAbsurdGameOver sets the status once an absurd game has finished.
+func (m *model) handleAbsurdGameOver() {
+	m.cursor = -1
+	if m.ws.IsWordGuessed() {
+		m.handleSetStatus(fmt.Sprintf("Cornered it in %d!\nPress ENTER to restart", m.ws.CurrGuess))
+		return
+	}
+	left := m.adversary.Candidates()
+	m.handleSetStatus(fmt.Sprintf(
+		"No more guesses :( It could still have been %s or %d others\nPress ENTER to restart",
+		left[0],
+		len(left)-1,
+	))
+}
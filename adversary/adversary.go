@@ -0,0 +1,54 @@
+// Package adversary implements an Absurdle style opponent that never picks a
+// word, and instead gives whatever feedback keeps the most words possible.
+package adversary
+
+import (
+	"github.com/bianxm/godle/solver"
+	"github.com/bianxm/godle/wordle"
+)
+
+// Adversary is a wordle.Answerer that dodges guesses. For every guess, it
+// groups the words that are still possible by the feedback the guess would
+// get, and keeps the biggest group. The game is only won once a single word
+// is left and it gets guessed.
+type Adversary struct {
+	candidates []string
+}
+
+// New returns an Adversary that can pretend to have picked any of candidates.
+func New(candidates []string) *Adversary {
+	return &Adversary{candidates: append([]string(nil), candidates...)}
+}
+
+// Score gives g the feedback that leaves the most candidates. Ties go to the
+// feedback that gives the least away, so the guess is only marked as right
+// when there's nothing else left.
+func (a *Adversary) Score(g wordle.Guess) {
+	if len(a.candidates) == 0 {
+		// nothing fits, so nothing can be right
+		for i := range g {
+			g[i].Status = wordle.Absent
+		}
+		return
+	}
+
+	buckets := solver.Partition(g.Word(), a.candidates)
+	best := -1
+	for p, words := range buckets {
+		if best == -1 || len(words) > len(buckets[best]) || (len(words) == len(buckets[best]) && p < best) {
+			best = p
+		}
+	}
+	a.candidates = buckets[best]
+	g.UpdateLettersWithWord([]byte(a.candidates[0]))
+}
+
+// Remaining returns how many words are still possible.
+func (a *Adversary) Remaining() int {
+	return len(a.candidates)
+}
+
+// Candidates returns a copy of the words that are still possible.
+func (a *Adversary) Candidates() []string {
+	return append([]string(nil), a.candidates...)
+}
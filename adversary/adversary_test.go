@@ -0,0 +1,41 @@
+package adversary
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bianxm/godle/wordle"
+)
+
+func TestScore(t *testing.T) {
+	a := New([]string{"BATCH", "CATCH", "HATCH", "LATCH", "MATCH", "PATCH"})
+	ws := wordle.NewAnswererState(a, wordle.Rules{WordSize: 5})
+
+	tests := []struct {
+		guess     string
+		remaining []string
+	}{
+		// BATCH, HATCH and LATCH all get the same feedback for CHAMP, which
+		// is the biggest group
+		{"CHAMP", []string{"BATCH", "HATCH", "LATCH"}},
+		// guessing a candidate fails while there are others left
+		{"BATCH", []string{"HATCH", "LATCH"}},
+		// HATCH and LATCH are a tie, so HATCH gets the worse news
+		{"HATCH", []string{"LATCH"}},
+		// with one word left, it has to admit it
+		{"LATCH", []string{"LATCH"}},
+	}
+	for i, test := range tests {
+		g := wordle.NewGuess(test.guess)
+		ws.Score(g)
+		if err := ws.AppendGuess(g); err != nil {
+			t.Fatalf("Error: %s", err)
+		}
+		if got := a.Candidates(); !reflect.DeepEqual(got, test.remaining) {
+			t.Errorf("after %s, Candidates() = %v, want %v", test.guess, got, test.remaining)
+		}
+		if won := i == len(tests)-1; ws.IsWordGuessed() != won {
+			t.Errorf("after %s, IsWordGuessed() = %t, want %t", test.guess, !won, won)
+		}
+	}
+}
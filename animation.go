@@ -0,0 +1,152 @@
+package main
+
+import (
+	"time"
+
+	"github.com/bianxm/godle/wordle"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// animationKind is what an animation does to its row.
+type animationKind int
+
+const (
+	// animFlip turns a guessed row's tiles over one by one to show their
+	// colours.
+	animFlip animationKind = iota
+	// animShake shakes the row being typed after an invalid guess.
+	animShake
+	// animBounce makes the tiles of a winning row hop one after another.
+	animBounce
+)
+
+// animFrame is how long each frame of an animation shows for.
+const animFrame = 60 * time.Millisecond
+
+const (
+	// flipStagger is how many frames after a tile the next tile starts
+	// flipping. Each tile shows edge on for a frame halfway through.
+	flipStagger = 2
+	// bounceHeight is how many frames a bouncing tile stays up.
+	bounceHeight = 2
+)
+
+// shakeOffsets are the left margins of a shaking row in each frame. The row
+// is normally one column in.
+var shakeOffsets = []int{2, 0, 2, 0, 2, 0, 1}
+
+// animation is an animation playing on one row of the board. Input is ignored
+// until it's done.
+type animation struct {
+	// id tells this animation's frames from an earlier one's.
+	id    int
+	kind  animationKind
+	row   int
+	frame int
+	// frames is how many frames the animation lasts.
+	frames int
+	// ticking is set while the next frame is on its way.
+	ticking bool
+}
+
+// msgAnimFrame is sent when the animation with the given id should move on to
+// its next frame.
+type msgAnimFrame struct {
+	id int
+}
+
+// handleStartAnimation starts an animation on row, unless animations are off.
+func (m *model) handleStartAnimation(kind animationKind, row int) {
+	if !m.animate {
+		return
+	}
+	size := m.rules.WordSize
+	frames := len(shakeOffsets)
+	switch kind {
+	case animFlip:
+		frames = (size-1)*flipStagger + 2
+	case animBounce:
+		frames = size + bounceHeight
+	}
+	m.animSeq++
+	m.anim = &animation{id: m.animSeq, kind: kind, row: row, frames: frames}
+}
+
+// handleAnimationTick returns a tea.Cmd for the next frame of the animation
+// that's playing, unless it's already on its way.
+func (m *model) handleAnimationTick() tea.Cmd {
+	if m.anim == nil || m.anim.ticking {
+		return nil
+	}
+	m.anim.ticking = true
+	id := m.anim.id
+	return tea.Tick(animFrame, func(time.Time) tea.Msg {
+		return msgAnimFrame{id: id}
+	})
+}
+
+// handleAnimFrame moves the animation on a frame. A winning row bounces once
+// it has flipped.
+func (m *model) handleAnimFrame(msg msgAnimFrame) {
+	a := m.anim
+	if a == nil || a.id != msg.id {
+		return
+	}
+	a.ticking = false
+	a.frame++
+	if a.frame < a.frames {
+		return
+	}
+	m.anim = nil
+	if a.kind == animFlip && m.ws.IsWordGuessed() && a.row == m.ws.CurrGuess-1 {
+		m.handleStartAnimation(animBounce, a.row)
+	}
+}
+
+// animating reports whether an animation of the given kind is playing on row.
+func (m *model) animating(kind animationKind, row int) bool {
+	return m.anim != nil && m.anim.kind == kind && m.anim.row == row
+}
+
+// renderFlippingGuess draws a guess whose tiles are being turned over: tiles
+// yet to flip look like they did while being typed, then show edge on for a
+// frame, then show their colour.
+func (m *model) renderFlippingGuess(g wordle.Guess) string {
+	letterBoxes := make([]string, len(g))
+	for i, l := range g {
+		switch start := i * flipStagger; {
+		case m.anim.frame < start:
+			letterBoxes[i] = m.theme.renderLetterBox(string(l.Char), m.theme.Text)
+		case m.anim.frame == start:
+			letterBoxes[i] = m.theme.renderLetterBox("─", m.theme.Muted)
+		default:
+			letterBoxes[i] = m.theme.renderTile(string(l.Char), l.Status)
+		}
+	}
+	return renderRowOfBoxes(letterBoxes)
+}
+
+// renderBouncingGuess draws a guess with its tiles hopping up one after
+// another.
+func (m *model) renderBouncingGuess(g wordle.Guess) string {
+	letterBoxes := make([]string, len(g))
+	for i, l := range g {
+		box := m.theme.renderTile(string(l.Char), l.Status)
+		if up := m.anim.frame - i; up >= 0 && up < bounceHeight {
+			box = m.theme.newStyle().MarginBottom(1).Render(box)
+		}
+		letterBoxes[i] = box
+	}
+	return renderRowOfBoxes(letterBoxes)
+}
+
+// renderRowOffset places a row of the board, shifting it side to side if it's
+// shaking.
+func (m *model) renderRowOffset(row int, s string) string {
+	left := 1
+	if m.animating(animShake, row) {
+		left = shakeOffsets[m.anim.frame]
+	}
+	return m.theme.newStyle().MarginLeft(left).MarginRight(2 - left).Render(s)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// finishAnimation sends frames until the animation of the given kind is done,
+// failing if it doesn't play.
+func finishAnimation(t *testing.T, m model, kind animationKind) model {
+	t.Helper()
+	if m.anim == nil || m.anim.kind != kind {
+		t.Fatalf("Animation = %+v, want kind %d", m.anim, kind)
+	}
+	id := m.anim.id
+	for i := 0; m.anim != nil && m.anim.id == id; i++ {
+		if i > 100 {
+			t.Fatalf("Animation %d never finished", kind)
+		}
+		m.View()
+		m, _ = update(m, msgAnimFrame{id: id})
+	}
+	return m
+}
+
+func TestFlipAnimation(t *testing.T) {
+	m := newTestModel("SLATE")
+	m.animate = true
+	m, cmd := submit(m, "CRANE")
+	if cmd == nil {
+		t.Errorf("Should return a tick for the animation, but didn't")
+	}
+
+	// input waits for the animation
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")})
+	if m.cursor != 0 {
+		t.Errorf("Typing during an animation moved the cursor to %d", m.cursor)
+	}
+
+	// an old frame doesn't move things on
+	frame := m.anim.frame
+	m, _ = update(m, msgAnimFrame{id: m.anim.id - 1})
+	if m.anim.frame != frame {
+		t.Errorf("Frame from another animation was used")
+	}
+
+	m = finishAnimation(t, m, animFlip)
+	if m.anim != nil {
+		t.Errorf("Nothing should follow a flip that didn't win, got %+v", m.anim)
+	}
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")})
+	if m.cursor != 1 {
+		t.Errorf("Typing after the animation should work, cursor = %d", m.cursor)
+	}
+}
+
+func TestWinAndShakeAnimations(t *testing.T) {
+	m := newTestModel("CRANE")
+	m.animate = true
+
+	m, _ = submit(m, "ABCDE")
+	m = finishAnimation(t, m, animShake)
+
+	for i := 0; i < 5; i++ {
+		m, _ = update(m, tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	m, _ = submit(m, "CRANE")
+	m = finishAnimation(t, m, animFlip)
+	m = finishAnimation(t, m, animBounce)
+	if m.anim != nil {
+		t.Errorf("Animation = %+v, want none after the bounce", m.anim)
+	}
+}
+
+func TestAnimationOff(t *testing.T) {
+	m, _ := submit(newTestModel("CRANE"), "SLATE")
+	if m.anim != nil {
+		t.Errorf("Animation = %+v, want none when they're off", m.anim)
+	}
+}
@@ -0,0 +1,328 @@
+// Package api serves godle games over HTTP as JSON, so that bots and
+// dashboards can play without a terminal.
+//
+// The endpoints are:
+//
+//	POST /games               start a game, from a NewGame
+//	GET  /games/{id}          get a game's State
+//	POST /games/{id}/guesses  play a Guess and get the game's State
+//
+// Failed requests get an Error with a code saying what went wrong.
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bianxm/godle/adversary"
+	"github.com/bianxm/godle/wordle"
+	"github.com/bianxm/godle/words"
+)
+
+// DefaultTTL is how long games are kept after they were last used, unless
+// the server is told otherwise.
+const DefaultTTL = time.Hour
+
+// DefaultMaxGames is how many games are kept at once, unless the server is
+// told otherwise.
+const DefaultMaxGames = 10000
+
+// maxBody is the most a request body can hold.
+const maxBody = 1 << 16
+
+const (
+	ModeClassic = "classic"
+	// ModeAbsurd games don't pick a word, and instead dodge every guess for
+	// as long as they can.
+	ModeAbsurd = "absurd"
+)
+
+// NewGame is the body of a request to start a game. Everything can be left
+// out to play a classic game with the standard rules.
+type NewGame struct {
+	Mode   string `json:"mode"`
+	Length int    `json:"length"`
+	// Seed picks the word, so the same seed gets the same game. If it's left
+	// out, it's picked at random.
+	Seed *int64 `json:"seed"`
+	// Guesses is how many guesses are allowed, or 0 for unlimited. It's six
+	// for classic games and unlimited for absurd ones unless it's set.
+	Guesses *int `json:"guesses"`
+	Hard    bool `json:"hard"`
+}
+
+// Guess is the body of a request to play a guess.
+type Guess struct {
+	Word string `json:"word"`
+}
+
+// State is a game as it's sent back.
+type State struct {
+	ID         string `json:"id"`
+	Mode       string `json:"mode"`
+	Seed       int64  `json:"seed"`
+	Length     int    `json:"length"`
+	MaxGuesses int    `json:"max_guesses"`
+	Hard       bool   `json:"hard"`
+	// Status is playing, won or lost.
+	Status  string        `json:"status"`
+	Guesses []GuessResult `json:"guesses"`
+	// Alphabet is the status each letter got the last time it was guessed,
+	// except that a letter stays correct once it has been. Letters that
+	// haven't been guessed are None.
+	Alphabet map[string]wordle.LetterStatus `json:"alphabet"`
+	// Answer is only sent once the game is over.
+	Answer string `json:"answer,omitempty"`
+}
+
+// GuessResult is a guess that was played and how each of its letters did.
+type GuessResult struct {
+	Word    string   `json:"word"`
+	Letters []Letter `json:"letters"`
+}
+
+type Letter struct {
+	Letter string              `json:"letter"`
+	Status wordle.LetterStatus `json:"status"`
+}
+
+const (
+	StatusPlaying = "playing"
+	StatusWon     = "won"
+	StatusLost    = "lost"
+)
+
+// Server is an http.Handler that plays games through the API. It's safe to
+// use from several goroutines.
+type Server struct {
+	games *store
+
+	// seeds picks the seeds of games that don't ask for one.
+	mu    sync.Mutex
+	seeds *rand.Rand
+}
+
+// NewServer returns a server that keeps up to maxGames games, and drops the
+// ones that haven't been used for ttl. It should be closed once it's done
+// with.
+func NewServer(ttl time.Duration, maxGames int) *Server {
+	s := &Server{
+		games: newStore(ttl, maxGames),
+		seeds: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	go s.games.sweep(sweepEvery(ttl))
+	return s
+}
+
+// Close stops the server dropping expired games. It doesn't stop any
+// requests being served, which should be finished first.
+func (s *Server) Close() error {
+	s.games.close()
+	return nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// paths are /games, /games/{id} and /games/{id}/guesses
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if parts[0] != "games" || len(parts) > 3 || (len(parts) == 3 && parts[2] != "guesses") {
+		writeError(w, newError(http.StatusNotFound, CodeNotFound, "No such endpoint"))
+		return
+	}
+
+	var st *State
+	var err error
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodPost:
+		st, err = s.handleNewGame(r)
+		if err == nil {
+			w.Header().Set("Location", "/games/"+st.ID)
+			writeJSON(w, http.StatusCreated, st)
+			return
+		}
+	case len(parts) == 2 && r.Method == http.MethodGet:
+		st, err = s.handleGetGame(parts[1])
+	case len(parts) == 3 && r.Method == http.MethodPost:
+		st, err = s.handleGuess(parts[1], r)
+	default:
+		err = newError(http.StatusMethodNotAllowed, CodeMethodNotAllowed, r.Method+" isn't allowed here")
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, st)
+}
+
+func (s *Server) handleNewGame(r *http.Request) (*State, error) {
+	var req NewGame
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if req.Mode == "" {
+		req.Mode = ModeClassic
+	}
+	if req.Mode != ModeClassic && req.Mode != ModeAbsurd {
+		return nil, newError(http.StatusBadRequest, CodeUnsupportedMode, fmt.Sprintf("Unknown mode %q, want %s or %s", req.Mode, ModeClassic, ModeAbsurd))
+	}
+
+	rules := wordle.DefaultRules()
+	if req.Length != 0 {
+		rules.WordSize = req.Length
+	}
+	if req.Mode == ModeAbsurd {
+		rules.MaxGuesses = 0
+	}
+	if req.Guesses != nil {
+		rules.MaxGuesses = *req.Guesses
+	}
+	rules.HardMode = req.Hard
+	if err := rules.Validate(); err != nil {
+		return nil, newError(http.StatusBadRequest, CodeBadRequest, err.Error())
+	}
+	dict, err := words.Builtin(rules.WordSize)
+	if err != nil {
+		return nil, newError(http.StatusBadRequest, CodeUnsupportedLength, fmt.Sprintf("No words with %d letters", rules.WordSize))
+	}
+
+	g := &game{mode: req.Mode}
+	if req.Seed != nil {
+		g.seed = *req.Seed
+	} else {
+		s.mu.Lock()
+		g.seed = s.seeds.Int63()
+		s.mu.Unlock()
+	}
+	var ws wordle.WordleState
+	if req.Mode == ModeAbsurd {
+		g.adversary = adversary.New(dict.Answers())
+		ws = wordle.NewAnswererState(g.adversary, rules)
+	} else {
+		ws = wordle.NewWordleState(dict.Random(rand.New(rand.NewSource(g.seed))), rules)
+	}
+	ws.Dict = dict
+	g.ws = &ws
+
+	if err := s.games.add(g); err != nil {
+		return nil, newError(http.StatusServiceUnavailable, CodeTooManyGames, "There are too many games being played, try again later")
+	}
+	// the game can be played as soon as it's stored
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.state(), nil
+}
+
+func (s *Server) handleGetGame(id string) (*State, error) {
+	g := s.games.get(id)
+	if g == nil {
+		return nil, errNoGame(id)
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.state(), nil
+}
+
+func (s *Server) handleGuess(id string, r *http.Request) (*State, error) {
+	var req Guess
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	g := s.games.get(id)
+	if g == nil {
+		return nil, errNoGame(id)
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.ws.IsWordGuessed() {
+		return nil, newError(http.StatusConflict, CodeGameOver, "The word has already been guessed")
+	}
+
+	guess := wordle.NewGuess(strings.ToUpper(strings.TrimSpace(req.Word)))
+	if err := g.ws.ValidateGuess(guess); err != nil {
+		return nil, guessError(err)
+	}
+	// the adversary narrows down its words when it scores, so the guess is
+	// only scored once it's known to be valid
+	g.ws.Score(guess)
+	if err := g.ws.AppendGuess(guess); err != nil {
+		return nil, guessError(err)
+	}
+	return g.state(), nil
+}
+
+// state returns the game as it's sent back. g.mu must be held.
+func (g *game) state() *State {
+	ws := g.ws
+	st := &State{
+		ID:         g.id,
+		Mode:       g.mode,
+		Seed:       g.seed,
+		Length:     ws.Rules.WordSize,
+		MaxGuesses: ws.Rules.MaxGuesses,
+		Hard:       ws.Rules.HardMode,
+		Status:     StatusPlaying,
+		Guesses:    make([]GuessResult, len(ws.Guesses)),
+		Alphabet:   make(map[string]wordle.LetterStatus, len(ws.Alphabet)),
+	}
+	for i, guess := range ws.Guesses {
+		res := GuessResult{Word: guess.Word(), Letters: make([]Letter, len(guess))}
+		for j, l := range guess {
+			res.Letters[j] = Letter{Letter: string(l.Char), Status: l.Status}
+		}
+		st.Guesses[i] = res
+	}
+	for c, ls := range ws.Alphabet {
+		st.Alphabet[string(c)] = ls
+	}
+
+	switch {
+	case ws.IsWordGuessed():
+		st.Status = StatusWon
+	case ws.ShouldEndGame():
+		st.Status = StatusLost
+	}
+	if st.Status != StatusPlaying {
+		st.Answer = string(ws.Word)
+		if g.adversary != nil {
+			// absurd games only settle on a word once it's guessed
+			st.Answer = g.adversary.Candidates()[0]
+		}
+	}
+	return st
+}
+
+func errNoGame(id string) *Error {
+	return newError(http.StatusNotFound, CodeNotFound, fmt.Sprintf("No game %q, or it has expired", id))
+}
+
+// decode reads the JSON body of r into v. An empty body leaves v as it is.
+func decode(r *http.Request, v any) error {
+	dec := json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxBody))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		return newError(http.StatusBadRequest, CodeBadRequest, "Invalid request body: "+err.Error())
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	var e *Error
+	if !errors.As(err, &e) {
+		e = newError(http.StatusInternalServerError, CodeInternal, err.Error())
+	}
+	writeJSON(w, e.status, struct {
+		Error *Error `json:"error"`
+	}{e})
+}
@@ -0,0 +1,265 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bianxm/godle/wordle"
+)
+
+// do sends a request with body encoded as JSON to srv, and decodes the
+// response into out.
+func do(t *testing.T, srv *httptest.Server, method, path string, body, out any) int {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req, err := http.NewRequest(method, srv.URL+path, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("%s %s: can't decode response: %v", method, path, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+// newTestServer returns a Server and an HTTP server serving it, which are
+// closed when the test finishes.
+func newTestServer(t *testing.T, ttl time.Duration, maxGames int) (*Server, *httptest.Server) {
+	t.Helper()
+	s := NewServer(ttl, maxGames)
+	srv := httptest.NewServer(s)
+	t.Cleanup(func() {
+		srv.Close()
+		s.Close()
+	})
+	return s, srv
+}
+
+type errorBody struct {
+	Error Error `json:"error"`
+}
+
+func newGame(t *testing.T, srv *httptest.Server, req NewGame) State {
+	t.Helper()
+	var st State
+	if code := do(t, srv, http.MethodPost, "/games", req, &st); code != http.StatusCreated {
+		t.Fatalf("POST /games = %d, want %d", code, http.StatusCreated)
+	}
+	return st
+}
+
+func TestPlayGame(t *testing.T) {
+	_, srv := newTestServer(t, DefaultTTL, DefaultMaxGames)
+
+	seed := int64(42)
+	st := newGame(t, srv, NewGame{Seed: &seed})
+	if st.Mode != ModeClassic || st.Length != 5 || st.MaxGuesses != 6 || st.Status != StatusPlaying || st.Answer != "" {
+		t.Errorf("New game = %+v, want a classic game in play with no answer", st)
+	}
+	if again := newGame(t, srv, NewGame{Seed: &seed}); again.ID == st.ID {
+		t.Errorf("Games should get their own ids")
+	}
+
+	var got State
+	if code := do(t, srv, http.MethodGet, "/games/"+st.ID, nil, &got); code != http.StatusOK || got.ID != st.ID {
+		t.Fatalf("GET /games/%s = %d %+v", st.ID, code, got)
+	}
+
+	// lose the game to find out the word
+	for _, word := range []string{"CRANE", "PIOUS", "BLIMP", "DWARF", "GHOST", "JUMBO"} {
+		var e errorBody
+		if code := do(t, srv, http.MethodPost, "/games/"+st.ID+"/guesses", Guess{Word: word}, &e); code != http.StatusOK {
+			t.Fatalf("Guessing %s = %d %+v", word, code, e.Error)
+		}
+	}
+	do(t, srv, http.MethodGet, "/games/"+st.ID, nil, &got)
+	if got.Answer == "" || len(got.Guesses) != 6 {
+		t.Fatalf("Game = %+v, want it over with six guesses", got)
+	}
+
+	// the same seed gets the same word, which can be won
+	won := newGame(t, srv, NewGame{Seed: &seed})
+	var st2 State
+	if code := do(t, srv, http.MethodPost, "/games/"+won.ID+"/guesses", Guess{Word: got.Answer}, &st2); code != http.StatusOK {
+		t.Fatalf("Guessing the answer = %d", code)
+	}
+	if st2.Status != StatusWon || st2.Answer != got.Answer {
+		t.Errorf("Status = %s with answer %q, want won with %q", st2.Status, st2.Answer, got.Answer)
+	}
+	first := st2.Answer[0:1]
+	if st2.Guesses[0].Letters[0] != (Letter{first, wordle.Correct}) || st2.Alphabet[first] != wordle.Correct {
+		t.Errorf("Letters = %+v and alphabet = %v, want %s correct", st2.Guesses[0].Letters, st2.Alphabet, first)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	_, srv := newTestServer(t, DefaultTTL, DefaultMaxGames)
+
+	guesses := 1
+	st := newGame(t, srv, NewGame{Hard: true, Guesses: &guesses})
+	tests := []struct {
+		method, path string
+		body         any
+		status       int
+		code         Code
+	}{
+		{http.MethodPost, "/games", NewGame{Mode: "speedrun"}, http.StatusBadRequest, CodeUnsupportedMode},
+		{http.MethodPost, "/games", NewGame{Length: 12}, http.StatusBadRequest, CodeUnsupportedLength},
+		{http.MethodPost, "/games", map[string]int{"colour": 1}, http.StatusBadRequest, CodeBadRequest},
+		{http.MethodGet, "/games/nope", nil, http.StatusNotFound, CodeNotFound},
+		{http.MethodGet, "/players", nil, http.StatusNotFound, CodeNotFound},
+		{http.MethodDelete, "/games/" + st.ID, nil, http.StatusMethodNotAllowed, CodeMethodNotAllowed},
+		{http.MethodPost, "/games/" + st.ID + "/guesses", Guess{Word: "HI"}, http.StatusUnprocessableEntity, CodeInvalidLength},
+		{http.MethodPost, "/games/" + st.ID + "/guesses", Guess{Word: "HHHHH"}, http.StatusUnprocessableEntity, CodeInvalidWord},
+	}
+	for _, test := range tests {
+		var e errorBody
+		status := do(t, srv, test.method, test.path, test.body, &e)
+		if status != test.status || e.Error.Code != test.code {
+			t.Errorf("%s %s = %d %s, want %d %s", test.method, test.path, status, e.Error.Code, test.status, test.code)
+		}
+	}
+
+	var e errorBody
+	do(t, srv, http.MethodPost, "/games/"+st.ID+"/guesses", Guess{Word: "CRANE"}, nil)
+	if status := do(t, srv, http.MethodPost, "/games/"+st.ID+"/guesses", Guess{Word: "CRANE"}, &e); status != http.StatusConflict {
+		t.Errorf("Guessing after the game = %d", status)
+	}
+	if e.Error.Code != CodeMaxGuesses && e.Error.Code != CodeGameOver {
+		t.Errorf("Guessing after the game = %s, want %s or %s", e.Error.Code, CodeMaxGuesses, CodeGameOver)
+	}
+}
+
+func TestHardModeError(t *testing.T) {
+	_, srv := newTestServer(t, DefaultTTL, DefaultMaxGames)
+
+	// absurd games never say a letter is correct on the first guess, so
+	// play until there's a hint to leave out
+	st := newGame(t, srv, NewGame{Mode: ModeAbsurd, Hard: true})
+	var hinted *Letter
+	for _, word := range []string{"CRANE", "PIOUS", "BLIMP", "DWARF", "GHOST"} {
+		do(t, srv, http.MethodPost, "/games/"+st.ID+"/guesses", Guess{Word: word}, &st)
+		for _, l := range st.Guesses[len(st.Guesses)-1].Letters {
+			if l.Status == wordle.Present || l.Status == wordle.Correct {
+				hinted = &l
+				break
+			}
+		}
+		if hinted != nil {
+			break
+		}
+	}
+	if hinted == nil {
+		t.Skip("No hints to leave out")
+	}
+	var e errorBody
+	for _, word := range []string{"FUZZY", "JAZZY", "MUMMY", "KIOSK"} {
+		if !bytes.Contains([]byte(word), []byte(hinted.Letter)) {
+			do(t, srv, http.MethodPost, "/games/"+st.ID+"/guesses", Guess{Word: word}, &e)
+			break
+		}
+	}
+	if e.Error.Code != CodeHardMode || e.Error.Letter != hinted.Letter || e.Error.Position == nil {
+		t.Errorf("Error = %+v, want hard_mode for %s", e.Error, hinted.Letter)
+	}
+}
+
+func TestConcurrentGuesses(t *testing.T) {
+	_, srv := newTestServer(t, DefaultTTL, DefaultMaxGames)
+
+	st := newGame(t, srv, NewGame{Mode: ModeAbsurd})
+	var wg sync.WaitGroup
+	for _, word := range []string{"CRANE", "PIOUS", "BLIMP", "DWARF", "GHOST", "JUMBO", "FUZZY", "KIOSK"} {
+		wg.Add(1)
+		go func(word string) {
+			defer wg.Done()
+			do(t, srv, http.MethodPost, "/games/"+st.ID+"/guesses", Guess{Word: word}, nil)
+			do(t, srv, http.MethodGet, "/games/"+st.ID, nil, nil)
+		}(word)
+	}
+	wg.Wait()
+	var got State
+	do(t, srv, http.MethodGet, "/games/"+st.ID, nil, &got)
+	if len(got.Guesses) != 8 {
+		t.Errorf("Game has %d guesses, want all 8", len(got.Guesses))
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	s, srv := newTestServer(t, time.Minute, DefaultMaxGames)
+	now := time.Now()
+	s.games.setNow(func() time.Time { return now })
+
+	kept := newGame(t, srv, NewGame{})
+	dropped := newGame(t, srv, NewGame{})
+	now = now.Add(50 * time.Second)
+	if code := do(t, srv, http.MethodGet, "/games/"+kept.ID, nil, nil); code != http.StatusOK {
+		t.Fatalf("GET before expiry = %d", code)
+	}
+	now = now.Add(20 * time.Second)
+
+	if code := do(t, srv, http.MethodGet, "/games/"+kept.ID, nil, nil); code != http.StatusOK {
+		t.Errorf("Game that was used should be kept, but GET = %d", code)
+	}
+	// starting a game clears out the old ones
+	newGame(t, srv, NewGame{})
+	if n := s.games.len(); n != 2 {
+		t.Errorf("%d games stored, want 2", n)
+	}
+	if code := do(t, srv, http.MethodGet, "/games/"+dropped.ID, nil, nil); code != http.StatusNotFound {
+		t.Errorf("Expired game GET = %d, want %d", code, http.StatusNotFound)
+	}
+}
+
+func TestMaxGames(t *testing.T) {
+	s, srv := newTestServer(t, time.Minute, 2)
+	now := time.Now()
+	s.games.setNow(func() time.Time { return now })
+
+	newGame(t, srv, NewGame{})
+	newGame(t, srv, NewGame{})
+	var body errorBody
+	if code := do(t, srv, http.MethodPost, "/games", NewGame{}, &body); code != http.StatusServiceUnavailable || body.Error.Code != CodeTooManyGames {
+		t.Errorf("POST /games when full = %d %+v, want %d %s", code, body.Error, http.StatusServiceUnavailable, CodeTooManyGames)
+	}
+	// there's room again once the old games expire
+	now = now.Add(2 * time.Minute)
+	newGame(t, srv, NewGame{})
+}
+
+func TestSweeper(t *testing.T) {
+	ttl := 20 * time.Millisecond
+	s, srv := newTestServer(t, ttl, DefaultMaxGames)
+	newGame(t, srv, NewGame{})
+	deadline := time.Now().Add(time.Second)
+	for s.games.len() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Expired game wasn't swept up without any requests")
+		}
+		time.Sleep(ttl)
+	}
+
+	s.Close()
+	newGame(t, srv, NewGame{})
+	time.Sleep(5 * ttl)
+	if n := s.games.len(); n != 1 {
+		t.Errorf("%d games stored after the server was closed, want the 1 left alone", n)
+	}
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/bianxm/godle/wordle"
+)
+
+// Code identifies the kind of error a request ran into, for clients to act
+// on. Messages can change, codes don't.
+type Code string
+
+const (
+	CodeBadRequest        Code = "bad_request"
+	CodeNotFound          Code = "not_found"
+	CodeMethodNotAllowed  Code = "method_not_allowed"
+	CodeUnsupportedMode   Code = "unsupported_mode"
+	CodeUnsupportedLength Code = "unsupported_length"
+	CodeGameOver          Code = "game_over"
+	CodeTooManyGames      Code = "too_many_games"
+	CodeInternal          Code = "internal"
+	// the codes for guesses AppendGuess turned down
+	CodeMaxGuesses    Code = "max_guesses_reached"
+	CodeInvalidLength Code = "invalid_guess_length"
+	CodeInvalidWord   Code = "invalid_word"
+	CodeHardMode      Code = "hard_mode"
+)
+
+// Error is the body of every response to a request that failed.
+type Error struct {
+	Code    Code   `json:"code"`
+	Message string `json:"message"`
+	// Letter and Position are set for hard_mode errors: the hinted letter
+	// that was left out, and where it has to go, or -1 if it can go
+	// anywhere.
+	Letter   string `json:"letter,omitempty"`
+	Position *int   `json:"position,omitempty"`
+
+	status int
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func newError(status int, code Code, message string) *Error {
+	return &Error{Code: code, Message: message, status: status}
+}
+
+// guessError turns an error from AppendGuess into an API error.
+func guessError(err error) *Error {
+	var hard *wordle.HardModeError
+	switch {
+	case errors.As(err, &hard):
+		e := newError(http.StatusUnprocessableEntity, CodeHardMode, err.Error())
+		e.Letter = string(hard.Letter)
+		e.Position = &hard.Position
+		return e
+	case errors.Is(err, wordle.ErrMaxGuesses):
+		return newError(http.StatusConflict, CodeMaxGuesses, err.Error())
+	case errors.Is(err, wordle.ErrInvalidLength):
+		return newError(http.StatusUnprocessableEntity, CodeInvalidLength, err.Error())
+	case errors.Is(err, wordle.ErrNotInDictionary):
+		return newError(http.StatusUnprocessableEntity, CodeInvalidWord, err.Error())
+	default:
+		return newError(http.StatusUnprocessableEntity, CodeBadRequest, err.Error())
+	}
+}
@@ -0,0 +1,161 @@
+package api
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/bianxm/godle/adversary"
+	"github.com/bianxm/godle/wordle"
+)
+
+// game is a game being played through the API. Its lock is held while it's
+// read or played.
+type game struct {
+	mu   sync.Mutex
+	id   string
+	mode string
+	seed int64
+	ws   *wordle.WordleState
+	// adversary scores guesses in absurd games.
+	adversary *adversary.Adversary
+	// expires is when the game is dropped unless it's used before then.
+	expires time.Time
+}
+
+// errFull is returned by add when the store already has as many games as it
+// can keep.
+var errFull = errors.New("too many games")
+
+// store keeps up to max games in memory, dropping the ones that haven't been
+// used for ttl.
+type store struct {
+	mu    sync.Mutex
+	games map[string]*game
+	ttl   time.Duration
+	max   int
+	now   func() time.Time
+
+	// stop is closed to stop the sweeper, which closes done once it has.
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+func newStore(ttl time.Duration, max int) *store {
+	return &store{
+		games: make(map[string]*game),
+		ttl:   ttl,
+		max:   max,
+		now:   time.Now,
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+}
+
+// sweepEvery returns how often expired games are dropped, so that games that
+// nobody comes back to don't hold on to memory.
+func sweepEvery(ttl time.Duration) time.Duration {
+	if ttl > time.Minute {
+		return time.Minute
+	}
+	return ttl
+}
+
+// sweep drops expired games every interval until the store is closed.
+func (s *store) sweep(interval time.Duration) {
+	defer close(s.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.mu.Lock()
+			s.evict(s.now())
+			s.mu.Unlock()
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// close stops the sweeper and waits for it to finish.
+func (s *store) close() {
+	s.stopOnce.Do(func() { close(s.stop) })
+	<-s.done
+}
+
+// add stores g under a new id, which it sets, and drops the games that have
+// expired. It returns errFull if there's no room for g.
+func (s *store) add(g *game) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	s.evict(now)
+	if len(s.games) >= s.max {
+		return errFull
+	}
+	for {
+		g.id = newID()
+		if _, ok := s.games[g.id]; !ok {
+			break
+		}
+	}
+	g.expires = now.Add(s.ttl)
+	s.games[g.id] = g
+	return nil
+}
+
+// get returns the game with id, or nil if there isn't one or it has expired.
+// Getting a game keeps it for another ttl.
+func (s *store) get(id string) *game {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	g, ok := s.games[id]
+	if !ok {
+		return nil
+	}
+	now := s.now()
+	if !now.Before(g.expires) {
+		delete(s.games, id)
+		return nil
+	}
+	g.expires = now.Add(s.ttl)
+	return g
+}
+
+// setNow replaces the clock the store checks expiry against.
+func (s *store) setNow(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+}
+
+// len returns how many games are stored, including any that have expired
+// but haven't been dropped yet.
+func (s *store) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.games)
+}
+
+// evict drops every game that has expired by now. s.mu must be held.
+func (s *store) evict(now time.Time) {
+	for id, g := range s.games {
+		if !now.Before(g.expires) {
+			delete(s.games, id)
+		}
+	}
+}
+
+// newID returns a random id that can't be guessed, since anyone with a game's
+// id can play it.
+func newID() string {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		panic("api: can't read random bytes: " + err.Error())
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"github.com/bianxm/godle/wordle"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// gameMode is the kind of game the TUI is playing.
+type gameMode int
+
+const (
+	// modeClassic is a regular game against a word picked by godle.
+	modeClassic gameMode = iota
+	// modeAssistant helps with a game played somewhere else: the player
+	// copies in each guess and its feedback, and godle keeps track of what
+	// the word could be.
+	modeAssistant
+	// modeReverse has the solver guess a word picked by the player.
+	modeReverse
+	// modeAbsurd plays against an adversary that avoids settling on a word
+	// for as long as it can.
+	modeAbsurd
+	// modeMulti plays several boards at once with the same guesses.
+	modeMulti
+	// modeRace races other players to the same word, on a server that
+	// scores the guesses.
+	modeRace
+)
+
+const markingStatus = "Copy the colours: ←/→ to move, SPACE to change, ENTER to submit"
+
+// handleStartAssistant switches to assistant mode with a fresh game.
+func (m *model) handleStartAssistant() {
+	m.mode = modeAssistant
+	m.daily = false
+	m.savePath = ""
+	m.gameOver = false
+	m.handleResetWordleState()
+	m.handleResetActiveGuess()
+	m.handleResetStatus()
+}
+
+// handleStartMarking checks the typed guess and lets the player mark the
+// colour of each of its tiles.
+func (m *model) handleStartMarking() {
+	g := wordle.NewGuess(string(m.activeGuess[:m.cursor]))
+	if err := m.ws.ValidateGuess(g); err != nil {
+		m.handleError(err)
+		m.handleStartAnimation(animShake, m.ws.CurrGuess)
+		return
+	}
+	for i := range g {
+		g[i].Status = wordle.Absent
+	}
+	m.marking = true
+	m.markGuess = g
+	m.markCursor = 0
+	m.handleSetStatus(markingStatus)
+}
+
+// handleMarkingKey handles a keypress while the player is marking tiles.
+func (m *model) handleMarkingKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyLeft:
+		if m.markCursor > 0 {
+			m.markCursor--
+		}
+	case tea.KeyRight:
+		if m.markCursor < len(m.markGuess)-1 {
+			m.markCursor++
+		}
+	case tea.KeySpace, tea.KeyUp:
+		l := &m.markGuess[m.markCursor]
+		l.Status = nextMark(l.Status)
+	case tea.KeyDown:
+		l := &m.markGuess[m.markCursor]
+		l.Status = prevMark(l.Status)
+	case tea.KeyEsc, tea.KeyBackspace:
+		// back to fixing the typed word, unless the computer picked it
+		if m.mode == modeAssistant {
+			m.marking = false
+			m.handleResetStatus()
+		}
+	case tea.KeyEnter:
+		return m.handleSubmitMarking()
+	}
+	return nil
+}
+
+// handleSubmitMarking appends the marked guess, and asks the solver what's
+// left once it has.
+func (m *model) handleSubmitMarking() tea.Cmd {
+	if err := m.ws.AppendGuess(m.markGuess); err != nil {
+		m.handleError(err)
+		return nil
+	}
+	m.marking = false
+	m.markGuess = nil
+	m.hint = nil
+	m.handleResetActiveGuess()
+	m.handleResetStatus()
+	m.handleShouldEndGame()
+	if m.gameOver {
+		return nil
+	}
+	if m.mode == modeReverse {
+		return m.handleComputerTurn(0)
+	}
+	return m.handleHint()
+}
+
+// nextMark cycles a tile through Absent, Present and Correct.
+func nextMark(ls wordle.LetterStatus) wordle.LetterStatus {
+	switch ls {
+	case wordle.Absent:
+		return wordle.Present
+	case wordle.Present:
+		return wordle.Correct
+	default:
+		return wordle.Absent
+	}
+}
+
+// prevMark cycles a tile the other way from nextMark.
+func prevMark(ls wordle.LetterStatus) wordle.LetterStatus {
+	switch ls {
+	case wordle.Correct:
+		return wordle.Present
+	case wordle.Present:
+		return wordle.Absent
+	default:
+		return wordle.Correct
+	}
+}
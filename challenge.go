@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bianxm/godle/wordle"
+	"github.com/bianxm/godle/words"
+)
+
+// handleChallengeResult finishes the challenge being played, so that new games
+// are free play, and returns a line for the status with the result code to
+// send back.
+func (m *model) handleChallengeResult() string {
+	code, err := words.EncodeResult(m.challenge, words.Result{
+		Solved:  m.ws.IsWordGuessed(),
+		Guesses: m.ws.CurrGuess,
+	})
+	m.challenge = ""
+	m.challengeWord = ""
+	if err != nil {
+		return "Couldn't make a result code: " + err.Error()
+	}
+	m.challengeResult = code
+	return "Result code " + code + " (S shares it too)"
+}
+
+// runChallenge makes a challenge code for a word, or reads a result code sent
+// back for one.
+func runChallenge(args []string) error {
+	rules := wordle.DefaultRules()
+	fs := newFlagSet("challenge", "challenge [flags] WORD\n  godle challenge -result RESULT CODE", `Make a code that challenges a friend to guess WORD, without giving it away.
+They play it with "godle play -challenge CODE", and get a result code to send
+back, which -result reads.`)
+	fs.IntVar(&rules.MaxGuesses, "guesses", rules.MaxGuesses, "number of guesses allowed, or 0 for unlimited")
+	fs.BoolVar(&rules.HardMode, "hard", false, "require revealed hints to be used in later guesses")
+	result := fs.String("result", "", "result code to read for the challenge code given")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errUsage
+	}
+
+	if *result != "" {
+		code := fs.Arg(0)
+		c, err := decodeChallenge(code)
+		if err != nil {
+			return err
+		}
+		r, err := words.DecodeResult(code, *result)
+		if err != nil {
+			return err
+		}
+		score := "X"
+		if r.Solved {
+			score = fmt.Sprint(r.Guesses)
+		}
+		if c.MaxGuesses > 0 {
+			score += fmt.Sprintf("/%d", c.MaxGuesses)
+		}
+		if r.Solved {
+			fmt.Printf("They guessed %s: %s\n", c.Word, score)
+		} else {
+			fmt.Printf("They didn't guess %s: %s\n", c.Word, score)
+		}
+		return nil
+	}
+
+	word := strings.ToUpper(fs.Arg(0))
+	rules.WordSize = len(word)
+	if err := checkRules(rules, nil); err != nil {
+		return err
+	}
+	d, _ := words.Builtin(rules.WordSize)
+	code, err := d.EncodeChallenge(words.Challenge{
+		Word:       word,
+		MaxGuesses: rules.MaxGuesses,
+		HardMode:   rules.HardMode,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println(code)
+	fmt.Printf("Send it to a friend to play with: godle play -challenge %s\n", code)
+	return nil
+}
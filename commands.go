@@ -0,0 +1,264 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/bianxm/godle/solver"
+	"github.com/bianxm/godle/stats"
+	"github.com/bianxm/godle/wordle"
+	"github.com/bianxm/godle/words"
+)
+
+// runStats prints the player's stats.
+func runStats(args []string) error {
+	cfg := defaultConfig()
+	fs := newFlagSet("stats", "stats [flags]", "Show your statistics and guess distribution.")
+	fs.StringVar(&cfg.statsPath, "file", cfg.statsPath, "stats file to read")
+	asJSON := fs.Bool("json", false, "print the stats as JSON")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return errUsage
+	}
+	if cfg.statsPath == "" {
+		return errors.New("Couldn't find the stats file")
+	}
+
+	st, err := stats.Load(cfg.statsPath)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		b, err := json.MarshalIndent(st, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	fmt.Printf("Played %d  Win %% %d  Current streak %d  Max streak %d\n",
+		st.Played, st.WinPercentage(), st.CurrentStreak, st.MaxStreak)
+	fmt.Println()
+	fmt.Println("Guess distribution")
+	most, longest := 1, wordle.DefaultMaxGuesses
+	for guesses, n := range st.Distribution {
+		if n > most {
+			most = n
+		}
+		if guesses > longest {
+			longest = guesses
+		}
+	}
+	for guesses := 1; guesses <= longest; guesses++ {
+		n := st.Distribution[guesses]
+		fmt.Printf("%2d %s %d\n", guesses, strings.Repeat("█", n*statsBarWidth/most), n)
+	}
+	return nil
+}
+
+// solveSuggestions is how many suggestions solve prints by default.
+const solveSuggestions = 10
+
+// runSolve prints the candidates and best next guesses for a game played
+// elsewhere.
+func runSolve(args []string) error {
+	rules := wordle.DefaultRules()
+	rules.MaxGuesses = 0
+	fs := newFlagSet("solve", "solve [flags] [WORD:FEEDBACK...]", `Suggest the next guess for a game played elsewhere. Give each guess so far
+with its feedback, one letter per tile: G for green, Y for yellow and anything
+else (like . or B) for grey. For example:
+
+  godle solve CRANE:..Y.G SLOTH:.Y...`)
+	fs.BoolVar(&rules.HardMode, "hard", false, "only suggest guesses that use every revealed hint")
+	n := fs.Int("n", solveSuggestions, "number of suggestions to print")
+	byRemaining := fs.Bool("remaining", false, "rank guesses by expected candidates left instead of information")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	guesses := make([]wordle.Guess, fs.NArg())
+	for i, arg := range fs.Args() {
+		g, err := parseFeedback(arg)
+		if err != nil {
+			return err
+		}
+		guesses[i] = g
+	}
+	if len(guesses) > 0 {
+		rules.WordSize = len(guesses[0])
+	}
+	if err := checkRules(rules, nil); err != nil {
+		return err
+	}
+	dict, _ := words.Builtin(rules.WordSize)
+
+	ws := wordle.NewAssistantState(rules)
+	for _, g := range guesses {
+		if err := ws.AppendGuess(g); err != nil {
+			return fmt.Errorf("%s: %v", g.Word(), err)
+		}
+	}
+	if ws.IsWordGuessed() {
+		fmt.Println("Solved!")
+		return nil
+	}
+
+	strategy := solver.MaxEntropy
+	if *byRemaining {
+		strategy = solver.MinExpectedRemaining
+	}
+	suggestions, left := solver.Suggest(&ws, *n, strategy)
+	if left == 0 {
+		all := dict.Guesses()
+		if err := solver.FindContradiction(all, ws.Guesses); err != nil {
+			return err
+		}
+		fmt.Println("No common words fit, but these do:")
+		fmt.Println(strings.Join(solver.Candidates(all, ws.Guesses), " "))
+		return nil
+	}
+
+	fmt.Printf("%d %s left\n", left, plural(left, "candidate", "candidates"))
+	if left <= solveSuggestions {
+		fmt.Println(strings.Join(solver.Candidates(dict.Answers(), ws.Guesses), " "))
+	}
+	fmt.Println()
+	for _, s := range suggestions {
+		mark := ""
+		if s.Candidate {
+			mark = " *"
+		}
+		fmt.Printf("%s  %.2f bits  %.1f left%s\n", s.Word, s.Entropy, s.ExpectedRemaining, mark)
+	}
+	return nil
+}
+
+// parseFeedback parses a guess and its feedback written as WORD:FEEDBACK.
+func parseFeedback(arg string) (wordle.Guess, error) {
+	word, feedback, ok := strings.Cut(arg, ":")
+	if !ok || word == "" || len(word) != len(feedback) {
+		return nil, fmt.Errorf("Couldn't read %q, want a word and one feedback letter per tile, like CRANE:..Y.G", arg)
+	}
+	for _, c := range word {
+		if !('A' <= c && c <= 'Z' || 'a' <= c && c <= 'z') {
+			return nil, fmt.Errorf("Couldn't read %q, guesses can only have the letters A to Z", arg)
+		}
+	}
+	g := wordle.NewGuess(strings.ToUpper(word))
+	for i := range g {
+		switch feedback[i] {
+		case 'G', 'g':
+			g[i].Status = wordle.Correct
+		case 'Y', 'y':
+			g[i].Status = wordle.Present
+		default:
+			g[i].Status = wordle.Absent
+		}
+	}
+	return g, nil
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
+
+// runShare prints the share summary of the last game played.
+func runShare(args []string) error {
+	cfg := defaultConfig()
+	fs := newFlagSet("share", "share [flags]", "Print the share summary of your last game, without revealing any letters.")
+	fs.BoolVar(&cfg.asciiShare, "ascii", false, "use plain characters instead of emoji")
+	copyIt := fs.Bool("copy", false, "also copy the summary to the clipboard")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return errUsage
+	}
+	if cfg.savePath == "" {
+		return errors.New("Couldn't find the saved game")
+	}
+
+	saved, err := loadGame(lastSavePath(cfg.savePath))
+	if err != nil {
+		return err
+	}
+	if saved == nil {
+		return errors.New("There's no game to share yet")
+	}
+	ws := saved.Game
+	if !ws.ShouldEndGame() {
+		return errors.New("The last game isn't finished yet")
+	}
+	share := ws.Share(cfg.asciiShare)
+	fmt.Println(share)
+	if *copyIt {
+		copyToClipboard(share, os.Stderr, os.Getenv)()
+	}
+	return nil
+}
+
+// servers maps the names accepted by serve to what runs them.
+var servers = map[string]func(args []string) error{}
+
+// runServe runs one of the servers.
+func runServe(args []string) error {
+	names := make([]string, 0, len(servers))
+	for name := range servers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fs := newFlagSet("serve", "serve <server> [flags]", "Host godle for other people. Available servers: "+listOrNone(names)+".")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errUsage
+	}
+	serve, ok := servers[fs.Arg(0)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Error: Unknown server %q\n\n", fs.Arg(0))
+		fs.Usage()
+		return errUsage
+	}
+	return serve(fs.Args()[1:])
+}
+
+// serveUntilStopped runs serve until it fails, or until godle is interrupted
+// or terminated, in which case it stops it with stop.
+func serveUntilStopped(serve, stop func() error) error {
+	done := make(chan os.Signal, 1)
+	signal.Notify(done, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(done)
+	errs := make(chan error, 1)
+	go func() {
+		errs <- serve()
+	}()
+	select {
+	case err := <-errs:
+		return err
+	case <-done:
+		return stop()
+	}
+}
+
+func listOrNone(names []string) string {
+	if len(names) == 0 {
+		return "none yet"
+	}
+	return strings.Join(names, ", ")
+}
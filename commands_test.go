@@ -0,0 +1,71 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bianxm/godle/wordle"
+)
+
+func TestParseFeedback(t *testing.T) {
+	A, P, C := wordle.Absent, wordle.Present, wordle.Correct
+	tests := []struct {
+		arg      string
+		word     string
+		statuses []wordle.LetterStatus
+	}{
+		{"CRANE:..Y.G", "CRANE", []wordle.LetterStatus{A, A, P, A, C}},
+		{"crane:gyBxg", "CRANE", []wordle.LetterStatus{C, P, A, A, C}},
+		{"SLOTH:GGGGG", "SLOTH", []wordle.LetterStatus{C, C, C, C, C}},
+	}
+	for _, test := range tests {
+		g, err := parseFeedback(test.arg)
+		if err != nil {
+			t.Errorf("parseFeedback(%q) returned error: %v", test.arg, err)
+			continue
+		}
+		if g.Word() != test.word {
+			t.Errorf("parseFeedback(%q) has word %s, want %s", test.arg, g.Word(), test.word)
+		}
+		for i, l := range g {
+			if l.Status != test.statuses[i] {
+				t.Errorf("parseFeedback(%q) has %v at %d, want %v", test.arg, l.Status, i, test.statuses[i])
+			}
+		}
+	}
+
+	errs := []struct {
+		arg  string
+		want string
+	}{
+		{"CRANE", "one feedback letter per tile"},
+		{"CRANE:..Y.", "one feedback letter per tile"},
+		{"CRANE:..Y.GG", "one feedback letter per tile"},
+		{":", "one feedback letter per tile"},
+		{"CR4NE:..Y.G", "only have the letters A to Z"},
+	}
+	for _, test := range errs {
+		_, err := parseFeedback(test.arg)
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("parseFeedback(%q) returned error %v, want one containing %q", test.arg, err, test.want)
+		}
+	}
+}
+
+func TestSolveArgs(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"CR4NE:..Y.G"}, "only have the letters A to Z"},
+		{[]string{"CRANE:..Y.G", "SLOTHS:......"}, "Invalid guess length"},
+		{[]string{"CR:..", "AB:.."}, "supported lengths"},
+		{[]string{"CRANE:GGGGY"}, "CRANE"},
+	}
+	for _, test := range tests {
+		err := runSolve(test.args)
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("runSolve(%q) returned error %v, want one containing %q", test.args, err, test.want)
+		}
+	}
+}
@@ -0,0 +1,342 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bianxm/godle/race"
+	"github.com/bianxm/godle/stats"
+	"github.com/bianxm/godle/wordle"
+	"github.com/bianxm/godle/words"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// config is everything about a TUI session that's picked on the command line.
+type config struct {
+	mode  gameMode
+	rules wordle.Rules
+	// boards is how many boards a multi-board game has.
+	boards int
+	// raceClient is connected to the race server in race mode, having joined
+	// the room raceRoom.
+	raceClient *race.Client
+	raceRoom   string
+
+	// daily picks the daily puzzle to start on, if it's set. Once that's
+	// finished, or if it's not set, words come from source.
+	daily words.WordSource
+	// source picks the words for free play. If it's nil, they're picked from
+	// dict at random.
+	source words.WordSource
+	// dict is the dictionary games are played with. If it's nil, that's the
+	// built-in one for the word length.
+	dict *words.Dictionary
+	// resume carries on with the saved game, if it's the same kind of game.
+	resume bool
+	// challenge is the code of a friend's challenge to start on, and
+	// challengeWord is its word. Once it's finished, words come from source.
+	challenge     string
+	challengeWord string
+
+	asciiShare bool
+	// debug turns on the debug panel, and debugLog is where it logs
+	// messages. If it's nil, they aren't logged anywhere.
+	debug    bool
+	debugLog io.Writer
+	// theme is the colours to draw the game in. If it's nil, it's dark or
+	// light to suit the terminal.
+	theme *Theme
+	// renderer draws the game, and clipboard is where it's copied to the
+	// clipboard from, with getenv looking up that terminal's environment.
+	// They're for the terminal godle's running in unless they're set.
+	renderer  *lipgloss.Renderer
+	clipboard io.Writer
+	getenv    func(string) string
+	// keyboard is the layout of the keyboard shown under the board.
+	keyboard keyboardLayout
+	// animate turns on tile animations.
+	animate bool
+
+	// statsPath and savePath are where stats and the current game are kept.
+	// Either can be empty to not keep them.
+	statsPath string
+	savePath  string
+}
+
+// defaultConfig returns the config for a free play game with the standard
+// rules, with stats and games kept in their usual places.
+func defaultConfig() config {
+	cfg := config{
+		rules:   wordle.DefaultRules(),
+		boards:  1,
+		resume:  true,
+		animate: true,
+		debug:   os.Getenv("GODLE_DEBUG") != "",
+	}
+	var err error
+	name := os.Getenv("GODLE_KEYBOARD")
+	if name == "" {
+		name = defaultKeyboard
+	}
+	cfg.keyboard, err = findKeyboard(name)
+	if err != nil {
+		// a layout we don't know is left for the -keyboard flag to fix
+		cfg.keyboard = keyboardLayouts[defaultKeyboard]
+	}
+	cfg.statsPath, err = stats.DefaultPath()
+	if err != nil {
+		// stats just won't be saved
+		cfg.statsPath = ""
+	}
+	cfg.savePath, err = defaultSavePath()
+	if err != nil {
+		// games just won't be saved
+		cfg.savePath = ""
+	}
+	return cfg
+}
+
+// modeNames maps the names accepted by -mode to game modes.
+var modeNames = map[string]gameMode{
+	"classic": modeClassic,
+	"assist":  modeAssistant,
+	"reverse": modeReverse,
+	"absurd":  modeAbsurd,
+	"multi":   modeMulti,
+}
+
+// modeFlag is a flag.Value that picks a game mode by name.
+type modeFlag struct {
+	mode *gameMode
+}
+
+func (f modeFlag) String() string {
+	if f.mode == nil {
+		return ""
+	}
+	return modeName(*f.mode)
+}
+
+// modeName returns the name -mode takes for mode, or "" if it doesn't take
+// one.
+func modeName(want gameMode) string {
+	for name, mode := range modeNames {
+		if mode == want {
+			return name
+		}
+	}
+	return ""
+}
+
+func (f modeFlag) Set(s string) error {
+	mode, ok := modeNames[strings.ToLower(s)]
+	if !ok {
+		return fmt.Errorf("unknown mode %q, want one of %s", s, strings.Join(modeList(), ", "))
+	}
+	*f.mode = mode
+	return nil
+}
+
+// modeList returns the names of the game modes in alphabetical order.
+func modeList() []string {
+	names := make([]string, 0, len(modeNames))
+	for name := range modeNames {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// addCommonFlags adds the flags shared by every command that starts the TUI
+// with rules of its own.
+func addCommonFlags(fs *flag.FlagSet, cfg *config) {
+	fs.IntVar(&cfg.rules.WordSize, "length", cfg.rules.WordSize, "number of letters in the word")
+	fs.BoolVar(&cfg.rules.HardMode, "hard", cfg.rules.HardMode, "require revealed hints to be used in later guesses")
+	addDisplayFlags(fs, cfg)
+}
+
+// addDisplayFlags adds the flags for how the TUI looks and behaves, which
+// every command that starts it has.
+func addDisplayFlags(fs *flag.FlagSet, cfg *config) {
+	fs.BoolVar(&cfg.asciiShare, "ascii", cfg.asciiShare, "share results with plain characters instead of emoji")
+	fs.BoolVar(&cfg.animate, "animate", cfg.animate, "animate tiles as they're revealed; -animate=false turns it off")
+	fs.Var(themeFlag{&cfg.theme}, "theme", "colours to draw the game in: auto, "+strings.Join(themeList(), ", ")+", or the name of a .toml or .json theme in the themes config directory")
+	fs.Var(keyboardFlag{&cfg.keyboard}, "keyboard", "layout of the keyboard under the board: "+strings.Join(keyboardList(), ", ")+" (also set by GODLE_KEYBOARD)")
+	fs.BoolVar(&cfg.debug, "debug", cfg.debug, "show the debug panel and log messages to a file (also enabled by setting GODLE_DEBUG)")
+}
+
+// runPlay starts a free play game.
+func runPlay(args []string) error {
+	cfg, err := playConfig(args)
+	if err != nil {
+		return err
+	}
+	return runTUI(cfg)
+}
+
+// playConfig returns the config for the game the play command's args ask for.
+func playConfig(args []string) (config, error) {
+	cfg := defaultConfig()
+	fs := newFlagSet("play", "play [flags]", "Play a game with a random word. Every mode except classic starts a fresh game\neach time, without saving it.")
+	addCommonFlags(fs, &cfg)
+	fs.Var(modeFlag{&cfg.mode}, "mode", "kind of game to play: "+strings.Join(modeList(), ", "))
+	fs.IntVar(&cfg.rules.MaxGuesses, "guesses", cfg.rules.MaxGuesses, "number of guesses allowed, or 0 for unlimited; absurd mode is unlimited and multi mode gets boards+5 unless this is set")
+	fs.IntVar(&cfg.boards, "boards", 4, "number of boards in multi mode, like Dordle (2) or Quordle (4)")
+	seed := fs.Int64("seed", 0, "seed for picking words, to get the same games again (default random)")
+	order := fs.String("order", "random", "how words are picked: random, shuffle (no repeats until every word has come up) or sequential (in word list order, for tournaments)")
+	wordList := fs.String("word-list", "", "file of answers to pick from instead of the built-in list, as text, JSON or gzip")
+	challenge := fs.String("challenge", "", "play a friend's challenge code, with the rules it was made with")
+	guessList := fs.String("guess-list", "", "file of allowed guesses to go with -word-list (default the answers and the built-in guesses)")
+	if err := parseFlags(fs, args); err != nil {
+		return config{}, err
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return config{}, errUsage
+	}
+
+	guessesSet, lengthSet := false, false
+	fs.Visit(func(f *flag.Flag) {
+		guessesSet = guessesSet || f.Name == "guesses"
+		lengthSet = lengthSet || f.Name == "length"
+	})
+	if cfg.mode != modeMulti {
+		cfg.boards = 1
+	}
+	if cfg.boards < 1 {
+		return config{}, errors.New("Need at least one board")
+	}
+	if !guessesSet {
+		switch cfg.mode {
+		case modeAbsurd:
+			cfg.rules.MaxGuesses = 0
+		case modeMulti:
+			cfg.rules.MaxGuesses = wordle.MultiMaxGuesses(cfg.boards)
+		}
+	}
+	if cfg.mode == modeMulti && cfg.rules.MaxGuesses == 0 {
+		return config{}, errors.New("Multi-board games need a guess limit")
+	}
+
+	if *challenge != "" {
+		if cfg.mode != modeClassic || *wordList != "" {
+			return config{}, errors.New("Challenges can only be played in classic mode with the built-in words")
+		}
+		c, err := decodeChallenge(*challenge)
+		if err != nil {
+			return config{}, err
+		}
+		cfg.rules = wordle.Rules{WordSize: len(c.Word), MaxGuesses: c.MaxGuesses, HardMode: c.HardMode}
+		cfg.challenge = *challenge
+		cfg.challengeWord = c.Word
+		// the word would be on disk for anyone to read, and resumed as free
+		// play
+		cfg.resume = false
+		cfg.savePath = ""
+	}
+	if *guessList != "" && *wordList == "" {
+		return config{}, errors.New("-guess-list needs a -word-list to go with it")
+	}
+	if *wordList != "" {
+		d, err := words.Load(*wordList, *guessList)
+		if err != nil {
+			return config{}, err
+		}
+		// the word list's length wins, unless -length says otherwise
+		if lengthSet && d.Length() != cfg.rules.WordSize {
+			return config{}, fmt.Errorf("%s has %d letter words, not %d", *wordList, d.Length(), cfg.rules.WordSize)
+		}
+		cfg.rules.WordSize = d.Length()
+		cfg.dict = d
+	}
+	if err := checkRules(cfg.rules, cfg.dict); err != nil {
+		return config{}, err
+	}
+	if cfg.dict == nil {
+		cfg.dict, _ = words.Builtin(cfg.rules.WordSize)
+	}
+
+	if *seed != 0 {
+		// a seeded game starts fresh, so it's the same game every time, and
+		// isn't saved over the player's free play game
+		cfg.resume = false
+		cfg.savePath = ""
+	} else {
+		*seed = time.Now().UnixNano()
+	}
+	r := rand.New(rand.NewSource(*seed))
+	switch *order {
+	case "random":
+		cfg.source = words.NewRandom(cfg.dict, r)
+	case "shuffle":
+		cfg.source = words.NewShuffleBag(cfg.dict, r)
+	case "sequential":
+		cfg.source = words.NewSequential(cfg.dict.Answers())
+	default:
+		return config{}, fmt.Errorf("Unknown order %q, want random, shuffle or sequential", *order)
+	}
+	return cfg, nil
+}
+
+// runDaily starts the daily puzzle.
+func runDaily(args []string) error {
+	cfg := defaultConfig()
+	fs := newFlagSet("daily", "daily [flags]", "Play the daily puzzle. Everyone playing on the same day with the same salt and\nlength gets the same word. Once it's finished, new games are free play.")
+	addCommonFlags(fs, &cfg)
+	salt := fs.String("salt", "", "salt mixed into the daily puzzle, so a team can share its own word of the day")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return errUsage
+	}
+	if err := checkRules(cfg.rules, nil); err != nil {
+		return err
+	}
+	cfg.dict, _ = words.Builtin(cfg.rules.WordSize)
+	cfg.daily = words.NewDaily(cfg.dict, *salt, nil)
+	return runTUI(cfg)
+}
+
+// checkRules checks that rules make sense and that there are words to play
+// them with, which are in dict or, if it's nil, the built-in dictionary.
+func checkRules(rules wordle.Rules, dict *words.Dictionary) error {
+	if err := rules.Validate(); err != nil {
+		return err
+	}
+	if dict != nil {
+		// every word picked is started with these rules, which need words
+		// of the right length
+		if dict.Length() != rules.WordSize {
+			return fmt.Errorf("The words have %d letters, not %d", dict.Length(), rules.WordSize)
+		}
+		return nil
+	}
+	if _, err := words.Builtin(rules.WordSize); err != nil {
+		return fmt.Errorf("%v (supported lengths: %v)", err, words.Lengths())
+	}
+	return nil
+}
+
+// decodeChallenge returns the challenge in a code made with the built-in
+// dictionary.
+func decodeChallenge(code string) (words.Challenge, error) {
+	length, err := words.ChallengeLength(code)
+	if err != nil {
+		return words.Challenge{}, err
+	}
+	d, err := words.Builtin(length)
+	if err != nil {
+		return words.Challenge{}, words.ErrBadChallenge
+	}
+	return d.DecodeChallenge(code)
+}
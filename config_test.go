@@ -0,0 +1,76 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// setTestEnv keeps godle's files and environment variables from the real
+// ones out of a test.
+func setTestEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("GODLE_DEBUG", "")
+	t.Setenv("GODLE_KEYBOARD", "")
+}
+
+func TestPlayConfig(t *testing.T) {
+	setTestEnv(t)
+	tests := []struct {
+		args    []string
+		mode    gameMode
+		boards  int
+		guesses int
+	}{
+		{nil, modeClassic, 1, 6},
+		{[]string{"-guesses", "8"}, modeClassic, 1, 8},
+		{[]string{"-mode", "absurd"}, modeAbsurd, 1, 0},
+		{[]string{"-mode", "absurd", "-guesses", "10"}, modeAbsurd, 1, 10},
+		{[]string{"-mode", "multi"}, modeMulti, 4, 9},
+		{[]string{"-mode", "multi", "-boards", "2"}, modeMulti, 2, 7},
+		{[]string{"-mode", "multi", "-guesses", "12"}, modeMulti, 4, 12},
+		// -boards only counts in multi mode
+		{[]string{"-mode", "reverse", "-boards", "3"}, modeReverse, 1, 6},
+	}
+	for _, test := range tests {
+		cfg, err := playConfig(test.args)
+		if err != nil {
+			t.Errorf("playConfig(%q) returned error: %v", test.args, err)
+			continue
+		}
+		if cfg.mode != test.mode || cfg.boards != test.boards || cfg.rules.MaxGuesses != test.guesses {
+			t.Errorf("playConfig(%q) = mode %v with %d boards and %d guesses, want mode %v with %d boards and %d guesses",
+				test.args, cfg.mode, cfg.boards, cfg.rules.MaxGuesses, test.mode, test.boards, test.guesses)
+		}
+	}
+}
+
+func TestPlayConfigErrors(t *testing.T) {
+	setTestEnv(t)
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"-mode", "multi", "-guesses", "0"}, "need a guess limit"},
+		{[]string{"-mode", "multi", "-boards", "0"}, "at least one board"},
+		{[]string{"-challenge", "CODE", "-mode", "absurd"}, "only be played in classic mode"},
+		{[]string{"-challenge", "CODE", "-word-list", "words.txt"}, "only be played in classic mode"},
+		{[]string{"-guess-list", "guesses.txt"}, "needs a -word-list"},
+		{[]string{"-length", "2"}, "supported lengths"},
+		{[]string{"-order", "backwards"}, "Unknown order"},
+	}
+	for _, test := range tests {
+		_, err := playConfig(test.args)
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("playConfig(%q) returned error %v, want one containing %q", test.args, err, test.want)
+		}
+	}
+
+	for _, args := range [][]string{{"extra"}, {"-mode", "chess"}, {"-unknown"}} {
+		if _, err := playConfig(args); !errors.Is(err, errUsage) {
+			t.Errorf("playConfig(%q) returned error %v, want errUsage", args, err)
+		}
+	}
+}
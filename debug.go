@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"strings"
+
+	"github.com/bianxm/godle/solver"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// debugMessages is how many recent messages the debug panel shows.
+const debugMessages = 10
+
+// debugState is kept by the model when debug mode is on. It's a pointer so
+// that it's shared between copies of the model.
+type debugState struct {
+	// show toggles the debug panel.
+	show bool
+	// msgs holds the most recent tea messages, oldest first.
+	msgs []string
+	// log logs every message, in full.
+	log *log.Logger
+}
+
+// handleEnableDebug turns on debug mode, logging messages to w. They aren't
+// logged anywhere if w is nil.
+func (m *model) handleEnableDebug(w io.Writer) {
+	if w == nil {
+		w = io.Discard
+	}
+	m.debug = &debugState{show: true, log: log.New(w, "godle ", log.LstdFlags)}
+}
+
+// handleDebugMsg records a message the model received.
+func (m *model) handleDebugMsg(msg tea.Msg) {
+	if m.debug == nil {
+		return
+	}
+	m.debug.log.Printf("%T %+v", msg, msg)
+
+	s := fmt.Sprintf("%T %+v", msg, msg)
+	// cut by runes, so a letter is never split in half
+	if r := []rune(s); len(r) > 60 {
+		s = string(r[:57]) + "..."
+	}
+	m.debug.msgs = append(m.debug.msgs, s)
+	if len(m.debug.msgs) > debugMessages {
+		m.debug.msgs = m.debug.msgs[1:]
+	}
+}
+
+// handleToggleDebug shows or hides the debug panel.
+func (m *model) handleToggleDebug() {
+	if m.debug != nil {
+		m.debug.show = !m.debug.show
+	}
+}
+
+func (m *model) renderDebug() string {
+	if m.debug == nil || !m.debug.show {
+		return ""
+	}
+	ws := m.ws
+	var b strings.Builder
+	b.WriteString("[DEBUG] ctrl+g to hide\n\n")
+
+	switch {
+	case m.mode == modeMulti:
+		for i, board := range m.multi.Boards {
+			candidates := solver.Candidates(m.dict.Answers(), board.Guesses)
+			fmt.Fprintf(&b, "Board %d: %s, %d candidates\n", i+1, board.Word, len(candidates))
+		}
+	case m.mode == modeAbsurd:
+		fmt.Fprintf(&b, "Adversary candidates: %d\n", m.adversary.Remaining())
+	default:
+		answer := string(ws.Word)
+		if ws.Word == nil {
+			answer = "(unknown)"
+		}
+		candidates := solver.Candidates(m.dict.Answers(), ws.Guesses)
+		fmt.Fprintf(&b, "Answer: %s\nCandidates: %d\n", answer, len(candidates))
+	}
+
+	fmt.Fprintf(&b, "\nRecent messages:\n")
+	for _, msg := range m.debug.msgs {
+		b.WriteString(msg + "\n")
+	}
+
+	return m.theme.newStyle().
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(m.theme.Muted).
+		Foreground(m.theme.Muted).
+		Padding(0, 1).
+		Width(64).
+		Render(strings.TrimRight(b.String(), "\n"))
+}
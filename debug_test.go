@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDebugOffByDefault(t *testing.T) {
+	setTestEnv(t)
+	if cfg := defaultConfig(); cfg.debug {
+		t.Errorf("Debug mode should be off without GODLE_DEBUG, but is on")
+	}
+	m, _ := update(newTestModel("CRANE"), tea.KeyMsg{Type: tea.KeyCtrlG})
+	if m.debug != nil || m.renderDebug() != "" {
+		t.Errorf("CTRL+G shouldn't show the debug panel unless debug mode is on")
+	}
+}
+
+func TestDebugPanel(t *testing.T) {
+	var logged bytes.Buffer
+	m := newTestModel("CRANE", func(cfg *config) {
+		cfg.debug = true
+		cfg.debugLog = &logged
+	})
+	if m.renderDebug() == "" {
+		t.Fatal("Debug panel should be showing, but isn't")
+	}
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyCtrlG})
+	if m.renderDebug() != "" {
+		t.Errorf("CTRL+G should hide the debug panel, but didn't")
+	}
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyCtrlG})
+	if m.renderDebug() == "" {
+		t.Errorf("CTRL+G should show the debug panel again, but didn't")
+	}
+
+	for i := 0; i < debugMessages+5; i++ {
+		m, _ = update(m, msgResetStatus{id: i})
+	}
+	if len(m.debug.msgs) != debugMessages || !strings.Contains(m.debug.msgs[debugMessages-1], "14") {
+		t.Errorf("Debug messages = %q, want the last %d", m.debug.msgs, debugMessages)
+	}
+
+	// every letter is two bytes, so cutting by bytes could split one
+	long := strings.Repeat("é", 100)
+	m, _ = update(m, long)
+	last := m.debug.msgs[len(m.debug.msgs)-1]
+	if !utf8.ValidString(last) || utf8.RuneCountInString(last) != 60 || !strings.HasSuffix(last, "...") {
+		t.Errorf("Long message was cut to %q, want 60 runes ending in ...", last)
+	}
+	if !strings.Contains(logged.String(), long) {
+		t.Errorf("Log should have the whole message, but is\n%s", logged.String())
+	}
+}
+
+func TestDebugLogDiscarded(t *testing.T) {
+	m := newTestModel("CRANE", func(cfg *config) { cfg.debug = true })
+	// there's nowhere to log to, which is fine
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyCtrlG})
+	if m.debug == nil || m.debug.show {
+		t.Errorf("Debug panel should be on and hidden, but debug = %+v", m.debug)
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/bianxm/godle/api"
+)
+
+func init() {
+	servers["http"] = runServeHTTP
+}
+
+// runServeHTTP serves the JSON API, for bots and dashboards.
+func runServeHTTP(args []string) error {
+	fs := newFlagSet("serve http", "serve http [flags]", `Serve a JSON API for playing godle from programs:
+
+  POST /games               start a game: {"mode", "length", "seed", "guesses", "hard"}
+  GET  /games/{id}          get a game
+  POST /games/{id}/guesses  play a guess: {"word"}
+
+Games are kept in memory, and dropped once they haven't been used for a while.
+Once there are -max-games of them, new games are turned away until some are.`)
+	addr := fs.String("addr", ":8080", "address to listen on")
+	ttl := fs.Duration("ttl", api.DefaultTTL, "how long to keep games that aren't being played")
+	maxGames := fs.Int("max-games", api.DefaultMaxGames, "most games to keep at once")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *ttl <= 0 {
+		fmt.Fprintln(os.Stderr, "Error: -ttl must be positive")
+		return errUsage
+	}
+	if *maxGames <= 0 {
+		fmt.Fprintln(os.Stderr, "Error: -max-games must be positive")
+		return errUsage
+	}
+
+	handler := api.NewServer(*ttl, *maxGames)
+	defer handler.Close()
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	fmt.Fprintf(os.Stderr, "Serving the godle API on %s\n", *addr)
+	err := serveUntilStopped(srv.ListenAndServe, func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return srv.Shutdown(ctx)
+	})
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
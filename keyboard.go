@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// keyboardLayout is the letter keys of a keyboard, a row at a time from the
+// top. Rows can be any length.
+type keyboardLayout struct {
+	name string
+	rows []string
+}
+
+// keyboardLayouts are the keyboards that can be shown under the board, by
+// name.
+var keyboardLayouts = map[string]keyboardLayout{}
+
+func registerKeyboard(name string, rows ...string) {
+	keyboardLayouts[name] = keyboardLayout{name: name, rows: rows}
+}
+
+func init() {
+	registerKeyboard("qwerty", "QWERTYUIOP", "ASDFGHJKL", "ZXCVBNM")
+	registerKeyboard("azerty", "AZERTYUIOP", "QSDFGHJKLM", "WXCVBN")
+	registerKeyboard("qwertz", "QWERTZUIOP", "ASDFGHJKL", "YXCVBNM")
+	registerKeyboard("dvorak", "PYFGCRL", "AOEUIDHTNS", "QJKXBMWVZ")
+	registerKeyboard("colemak", "QWFPGJLUY", "ARSTDHNEIO", "ZXCVBKM")
+}
+
+// defaultKeyboard is the layout used unless the -keyboard flag or
+// $GODLE_KEYBOARD picks another.
+const defaultKeyboard = "qwerty"
+
+// findKeyboard returns the layout called name.
+func findKeyboard(name string) (keyboardLayout, error) {
+	layout, ok := keyboardLayouts[strings.ToLower(name)]
+	if !ok {
+		return keyboardLayout{}, fmt.Errorf("unknown keyboard %q, want one of %s", name, strings.Join(keyboardList(), ", "))
+	}
+	return layout, nil
+}
+
+// keyboardList returns the names of the keyboard layouts in alphabetical
+// order.
+func keyboardList() []string {
+	names := make([]string, 0, len(keyboardLayouts))
+	for name := range keyboardLayouts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// keyboardFlag is a flag.Value that picks a keyboard layout by name.
+type keyboardFlag struct {
+	layout *keyboardLayout
+}
+
+func (f keyboardFlag) String() string {
+	if f.layout == nil {
+		return ""
+	}
+	return f.layout.name
+}
+
+func (f keyboardFlag) Set(s string) error {
+	layout, err := findKeyboard(s)
+	if err != nil {
+		return err
+	}
+	*f.layout = layout
+	return nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestKeyboardLayouts(t *testing.T) {
+	for name, layout := range keyboardLayouts {
+		letters := strings.Join(layout.rows, "")
+		seen := map[rune]bool{}
+		for _, c := range letters {
+			if c < 'A' || c > 'Z' || seen[c] {
+				t.Errorf("%s: %q isn't each letter once", name, letters)
+				break
+			}
+			seen[c] = true
+		}
+		if len(seen) != 26 {
+			t.Errorf("%s has %d letters, want 26", name, len(seen))
+		}
+	}
+}
+
+func TestRenderKeyboard(t *testing.T) {
+	m := newTestModel("CRANE")
+	m.keyboard = keyboardLayouts["dvorak"]
+	lines := strings.Split(m.renderAlphabet(), "\n")
+	if len(lines) != 9 {
+		t.Fatalf("Keyboard is %d lines, want 3 rows of boxes", len(lines))
+	}
+	if top := strings.Join(strings.Fields(strings.ReplaceAll(lines[1], "│", " ")), ""); top != "PYFGCRL" {
+		t.Errorf("Top row is %q, want PYFGCRL", top)
+	}
+}
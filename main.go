@@ -1,16 +1,178 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+
+	"github.com/bianxm/godle/xdg"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// command is a godle subcommand. run gets the arguments after the command's
+// name.
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) error
+}
+
+var commands []command
+
+func init() {
+	// set up here rather than in the declaration, since help refers back to
+	// commands
+	commands = []command{
+		{"play", "play a game with a random word", runPlay},
+		{"daily", "play the daily puzzle (the default)", runDaily},
+		{"stats", "show your statistics", runStats},
+		{"solve", "suggest the next guess for a game played elsewhere", runSolve},
+		{"share", "print the share summary of your last game", runShare},
+		{"challenge", "challenge a friend to guess a word you pick", runChallenge},
+		{"race", "race other players to the same word", runRace},
+		{"serve", "host godle for other people", runServe},
+		{"help", "show help for a command", runHelp},
+	}
+}
+
+// errUsage is returned by commands whose arguments were wrong, after they've
+// explained what they expected.
+var errUsage = errors.New("usage")
+
 func main() {
-	p := tea.NewProgram(initialModel())
-	if _, err := p.Run(); err != nil {
-		fmt.Printf("Error: %v", err)
+	args := os.Args[1:]
+	// plain "godle" and "godle -hard" and so on play the daily puzzle
+	name := "daily"
+	if len(args) > 0 && !isFlag(args[0]) {
+		name, args = args[0], args[1:]
+	} else if len(args) > 0 && (args[0] == "-h" || args[0] == "-help" || args[0] == "--help") {
+		usage()
+		return
+	}
+
+	cmd := findCommand(name)
+	if cmd == nil {
+		fmt.Fprintf(os.Stderr, "Error: Unknown command %q\n\n", name)
+		usage()
+		os.Exit(2)
+	}
+	err := cmd.run(args)
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+	case errors.Is(err, errUsage):
+		os.Exit(2)
+	default:
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+func isFlag(arg string) bool {
+	return len(arg) > 1 && arg[0] == '-'
+}
+
+func findCommand(name string) *command {
+	for i := range commands {
+		if commands[i].name == name {
+			return &commands[i]
+		}
+	}
+	return nil
+}
+
+// usage prints the top-level help.
+func usage() {
+	out := os.Stderr
+	fmt.Fprintln(out, "godle is Wordle in the terminal.")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Usage:")
+	fmt.Fprintln(out, "  godle [command] [flags]")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(out, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, `Run "godle help <command>" or "godle <command> --help" for a command's flags.`)
+}
+
+// runHelp shows the help for the named command, or the top-level help.
+func runHelp(args []string) error {
+	if len(args) == 0 {
+		usage()
+		return nil
+	}
+	cmd := findCommand(args[0])
+	if cmd == nil || cmd.name == "help" {
+		fmt.Fprintf(os.Stderr, "Error: Unknown command %q\n\n", args[0])
+		usage()
+		return errUsage
+	}
+	return cmd.run([]string{"-help"})
+}
+
+// newFlagSet returns a flag set for a command that prints usageLine, the
+// description and the flags on -h or --help.
+func newFlagSet(name, usageLine, description string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "Usage:\n  godle %s\n\n%s\n", usageLine, description)
+		hasFlags := false
+		fs.VisitAll(func(*flag.Flag) { hasFlags = true })
+		if hasFlags {
+			fmt.Fprintln(out, "\nFlags:")
+			fs.PrintDefaults()
+		}
+	}
+	return fs
+}
+
+// parseFlags parses args into fs, turning a bad flag into errUsage since the
+// flag package has already explained it.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	err := fs.Parse(args)
+	if err != nil && !errors.Is(err, flag.ErrHelp) {
+		return errUsage
+	}
+	return err
+}
+
+// runTUI plays the game described by cfg until the player quits.
+func runTUI(cfg config) error {
+	if cfg.debug {
+		logPath, err := debugLogPath()
+		if err != nil {
+			return err
+		}
+		f, err := os.OpenFile(logPath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		cfg.debugLog = f
+	}
+
+	if cfg.theme == nil {
+		cfg.theme = autoTheme(nil)
+	}
+	p := tea.NewProgram(initialModel(cfg))
+	_, err := p.Run()
+	return err
+}
+
+// debugLogPath returns where debug mode logs to: $GODLE_DEBUG_LOG if it's
+// set, otherwise debug.log in godle's data directory.
+func debugLogPath() (string, error) {
+	if path := os.Getenv("GODLE_DEBUG_LOG"); path != "" {
+		return path, nil
+	}
+	path, err := xdg.DataFile("debug.log")
+	if err != nil {
+		return "", err
+	}
+	return path, os.MkdirAll(filepath.Dir(path), 0o755)
+}
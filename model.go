@@ -2,6 +2,12 @@
 package main
 
 import (
+	"io"
+	"os"
+	"time"
+
+	"github.com/bianxm/godle/adversary"
+	"github.com/bianxm/godle/stats"
 	"github.com/bianxm/godle/wordle"
 	"github.com/bianxm/godle/words"
 
@@ -11,27 +17,197 @@ import (
 type model struct {
 	ws *wordle.WordleState
 
-	activeGuess [wordle.WordSize]byte
+	mode  gameMode
+	rules wordle.Rules
+	// daily is set while the player is on the daily puzzle from
+	// dailySource; once it is finished, new games are free play with words
+	// from source.
+	daily       bool
+	dailySource words.WordSource
+	source      words.WordSource
+
+	// challenge is the code of the friend's challenge being played, and
+	// challengeWord is its word. Once it's finished, new games are free play.
+	challenge     string
+	challengeWord string
+	// challengeResult is the result code of the challenge just finished,
+	// which is shared along with the game.
+	challengeResult string
+
+	// dict is the dictionary guesses are checked against.
+	dict *words.Dictionary
+
+	// theme is the colours the game is drawn in.
+	theme *Theme
+	// clipboard is where share summaries are copied to the clipboard, and
+	// getenv looks up the environment of the terminal it goes to.
+	clipboard io.Writer
+	getenv    func(string) string
+	// keyboard is the layout of the keyboard under the board.
+	keyboard keyboardLayout
+
+	// asciiShare shares results with plain characters instead of emoji.
+	asciiShare bool
+
+	activeGuess []byte
 	cursor      int
+	// suggestions are words the last guess might have been a typo for, if
+	// it wasn't a word. TAB puts the first of them in the row.
+	suggestions []string
+
+	// marking is set in assistant mode while the player marks the colours
+	// of markGuess, with markCursor on the tile being marked.
+	marking    bool
+	markGuess  wordle.Guess
+	markCursor int
+
+	// choosing is set in reverse mode while the player picks a word for the
+	// computer to guess. reverseRemaining is how many words the computer
+	// thinks are left.
+	choosing         bool
+	reverseRemaining int
+
+	// adversary scores guesses in absurd mode.
+	adversary *adversary.Adversary
+
+	// multi holds every board in multi-board mode, where ws is the first of
+	// them.
+	multi  *wordle.MultiState
+	boards int
+
+	// race is the connection to the race server and everyone's boards in
+	// race mode.
+	race *raceState
+
+	// stats are saved to statsPath after every game, unless it's empty.
+	stats     stats.Stats
+	statsPath string
+
+	// the game is saved to savePath after every guess, unless it's empty.
+	// It's saved with the mode, which has to match to resume it.
+	savePath string
+
+	// hint holds the solver's suggestions for the current guess, if the
+	// player asked for them.
+	hint *msgHint
 
-	status        string
-	statusPending int
+	// status is the message under the title, unless there are notices, in
+	// which case the first one shows instead. noticeSeq numbers the notices
+	// and noticeTimer is the one being timed.
+	status      string
+	notices     []notice
+	noticeSeq   int
+	noticeTimer int
+	// rowError highlights the row being typed after an error.
+	rowError bool
 
 	width  int
 	height int
 
+	// anim is the animation playing, if there is one, and animSeq numbers
+	// them. animate is off if the player doesn't want animations.
+	anim    *animation
+	animSeq int
+	animate bool
+
+	// debug is nil unless debug mode is on.
+	debug *debugState
+
 	gameOver bool
 }
 
 func (m model) Init() tea.Cmd {
-	return nil
+	// there may be a notice from starting up, which Update starts the timer
+	// for, since changes to m here are thrown away
+	started := func() tea.Msg { return msgStarted{} }
+	cmds := []tea.Cmd{started, prepareSuggestions(m.dict)}
+	if m.race != nil {
+		cmds = append(cmds, waitForRace(m.race.client))
+	}
+	return tea.Batch(cmds...)
 }
 
-func initialModel() model {
-	ws := wordle.NewWordleState(words.GetWord())
+// initialModel returns the model for the game described by cfg, resuming the
+// saved game if it's the same kind of game.
+func initialModel(cfg config) model {
+	dict := cfg.dict
+	if dict == nil {
+		// the rules were checked against the built-in dictionary on startup
+		dict, _ = words.Builtin(cfg.rules.WordSize)
+	}
+	theme := cfg.theme
+	if theme == nil {
+		theme = &themeDark
+	}
+	clipboard, getenv := cfg.clipboard, cfg.getenv
+	if clipboard == nil {
+		clipboard = os.Stderr
+	}
+	if getenv == nil {
+		getenv = os.Getenv
+	}
+	keyboard := cfg.keyboard
+	if keyboard.rows == nil {
+		keyboard = keyboardLayouts[defaultKeyboard]
+	}
+	source := cfg.source
+	if source == nil {
+		source = words.NewSeeded(dict, time.Now().UnixNano())
+	}
 	m := model{
-		ws:     &ws,
-		status: "Guess the word!",
+		rules:         cfg.rules,
+		daily:         cfg.daily != nil,
+		dailySource:   cfg.daily,
+		dict:          dict,
+		source:        source,
+		challenge:     cfg.challenge,
+		challengeWord: cfg.challengeWord,
+		theme:         theme.withRenderer(cfg.renderer),
+		clipboard:     clipboard,
+		getenv:        getenv,
+		keyboard:      keyboard,
+		asciiShare:    cfg.asciiShare,
+		animate:       cfg.animate,
+		savePath:      cfg.savePath,
+		activeGuess:   make([]byte, cfg.rules.WordSize),
+		status:        "Guess the word!",
+	}
+	switch cfg.mode {
+	case modeAssistant:
+		m.handleStartAssistant()
+	case modeReverse:
+		m.handleStartReverse()
+	case modeAbsurd:
+		m.handleStartAbsurd()
+	case modeMulti:
+		m.handleStartMulti(cfg.boards)
+	case modeRace:
+		m.handleStartRace(cfg.raceClient, cfg.raceRoom)
+	default:
+		m.handleResetWordleState()
+		if m.savePath != "" && cfg.resume {
+			m.handleResumeGame()
+		}
+	}
+	if cfg.statsPath != "" {
+		st, err := stats.Load(cfg.statsPath)
+		if err != nil {
+			// keep playing, but don't overwrite stats we couldn't read
+			m.handleNotify(err.Error(), severityError, errorDuration)
+		} else {
+			m.stats = st
+			m.statsPath = cfg.statsPath
+		}
+	}
+	if cfg.debug {
+		m.handleEnableDebug(cfg.debugLog)
 	}
 	return m
 }
+
+// handleNewState makes ws the current game, checking its guesses against the
+// model's dictionary.
+func (m *model) handleNewState(ws *wordle.WordleState) {
+	ws.Dict = m.dict
+	m.ws = ws
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/bianxm/godle/wordle"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// handleStartMulti switches to multi-board mode with n boards.
+func (m *model) handleStartMulti(n int) {
+	m.mode = modeMulti
+	m.boards = n
+	m.daily = false
+	m.savePath = ""
+	m.gameOver = false
+	m.handleResetActiveGuess()
+	m.handleResetStatus()
+	// last, so that the notice if the boards can't be started stays up
+	m.handleResetWordleState()
+}
+
+// maxMultiDraws is how many words per board are drawn looking for ones that
+// aren't on another board, before repeats are allowed. Sources like Fixed
+// never come up with another word.
+const maxMultiDraws = 10
+
+// handleResetMultiState starts a new set of boards, each with a different
+// word if the source comes up with enough of them.
+func (m *model) handleResetMultiState() {
+	picked := make(map[string]bool)
+	list := make([]string, 0, m.boards)
+	for draws := 0; len(list) < m.boards; draws++ {
+		word := m.source.Next().Word
+		if picked[word] && draws < maxMultiDraws*m.boards {
+			continue
+		}
+		picked[word] = true
+		list = append(list, word)
+	}
+	multi, err := wordle.NewMultiState(list, m.rules)
+	if err != nil {
+		m.handleError(fmt.Errorf("Couldn't start the boards: %w", err))
+		if m.multi != nil {
+			return
+		}
+		// there has to be a board to draw, even though it can't be played
+		ws := wordle.NewAssistantState(m.rules)
+		multi = &wordle.MultiState{Boards: []*wordle.WordleState{&ws}}
+	}
+	m.multi = multi
+	for _, ws := range m.multi.Boards {
+		ws.Dict = m.dict
+	}
+	// the first board stands in for the game wherever only one is needed
+	m.ws = m.multi.Boards[0]
+}
+
+// handleSubmitMultiGuess plays the typed guess on every unsolved board.
+func (m *model) handleSubmitMultiGuess() {
+	if err := m.multi.AppendGuess(string(m.activeGuess[:m.cursor])); err != nil {
+		m.handleError(err)
+		return
+	}
+	m.handleResetStatus()
+	m.handleResetActiveGuess()
+}
+
+// handleMultiGameOver sets the status once every board is solved or the
+// guesses have run out.
+func (m *model) handleMultiGameOver() {
+	m.cursor = -1
+	if m.multi.IsWordGuessed() {
+		m.handleSetStatus(fmt.Sprintf(
+			"Solved all %d boards in %d guesses!\nPress ENTER to restart",
+			len(m.multi.Boards),
+			m.multi.CurrGuess,
+		))
+		return
+	}
+	answers := make([]string, len(m.multi.Boards))
+	for i, ws := range m.multi.Boards {
+		answers[i] = string(ws.Word)
+	}
+	m.handleSetStatus(fmt.Sprintf(
+		"No more guesses :( Solved %d of %d. Words were %s\nPress ENTER to restart",
+		m.multi.Solved(),
+		len(m.multi.Boards),
+		strings.Join(answers, ", "),
+	))
+}
+
+// multiBoardGap is the space between boards.
+const multiBoardGap = 2
+
+// renderMultiBoards tiles every board, fitting as many side by side as the
+// terminal is wide.
+func (m *model) renderMultiBoards() string {
+	boards := make([]string, len(m.multi.Boards))
+	for i, ws := range m.multi.Boards {
+		boards[i] = m.renderMultiBoard(ws)
+	}
+
+	perRow := len(boards)
+	if m.width > 0 {
+		boardWidth := lipgloss.Width(boards[0]) + multiBoardGap
+		perRow = m.width / boardWidth
+	}
+	if perRow < 1 {
+		perRow = 1
+	}
+	if perRow > len(boards) {
+		perRow = len(boards)
+	}
+
+	gap := strings.Repeat(" ", multiBoardGap)
+	var rows []string
+	for i := 0; i < len(boards); i += perRow {
+		end := i + perRow
+		if end > len(boards) {
+			end = len(boards)
+		}
+		var row []string
+		for j, b := range boards[i:end] {
+			if j > 0 {
+				row = append(row, gap)
+			}
+			row = append(row, b)
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+	}
+	return lipgloss.JoinVertical(lipgloss.Center, rows...)
+}
+
+// renderMultiBoard draws one board with a line per guess, so that several
+// fit on screen at once. Once a board is solved it doesn't show the guesses
+// after it.
+func (m *model) renderMultiBoard(ws *wordle.WordleState) string {
+	rows := make([]string, ws.Rules.MaxGuesses)
+	solved := ws.IsWordGuessed()
+	for i := range rows {
+		tiles := make([]string, ws.Rules.WordSize)
+		for j := range tiles {
+			letter, style := " ", m.theme.newStyle().Foreground(m.theme.Text)
+			if i < ws.CurrGuess {
+				l := ws.Guesses[i][j]
+				letter = string(l.Char)
+				if m.theme.Marks {
+					style = m.theme.statusStyle(l.Status)
+				} else {
+					style = style.Background(m.theme.statusColor(l.Status)).Foreground(m.theme.TileText)
+				}
+			} else if i == ws.CurrGuess && !solved && !m.gameOver {
+				if m.rowError {
+					style = style.Foreground(m.theme.Error)
+				}
+				if j < m.cursor {
+					letter = string(m.activeGuess[j])
+				} else if j == m.cursor {
+					letter = "_"
+				}
+			} else {
+				letter, style = "·", style.Foreground(m.theme.Muted)
+			}
+			tiles[j] = style.Padding(0, 1).Render(letter)
+		}
+		rows[i] = lipgloss.JoinHorizontal(lipgloss.Top, tiles...)
+	}
+	style := m.theme.newStyle().Border(lipgloss.RoundedBorder()).BorderForeground(m.theme.Muted)
+	if solved {
+		style = style.BorderForeground(m.theme.Correct)
+		if m.theme.Marks {
+			style = style.Border(lipgloss.DoubleBorder())
+		}
+	}
+	return style.Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
+
+// renderMultiAlphabet draws the keyboard with each key split into a small grid
+// of cells, one per board, coloured by how the letter did on that board.
+func (m *model) renderMultiAlphabet() string {
+	n := len(m.multi.Boards)
+	cols := int(math.Ceil(math.Sqrt(float64(n))))
+	gridRows := (n + cols - 1) / cols
+
+	rr := make([]string, len(m.keyboard.rows))
+	for i, chars := range m.keyboard.rows {
+		keys := make([]string, len(chars))
+		for j, c := range chars {
+			cells := make([]string, gridRows)
+			for r := range cells {
+				var line strings.Builder
+				for k := r * cols; k < (r+1)*cols; k++ {
+					if k >= n {
+						line.WriteString(" ")
+						continue
+					}
+					ls := m.multi.Boards[k].Alphabet[byte(c)]
+					line.WriteString(m.theme.newStyle().Foreground(m.theme.statusColor(ls)).Render(m.theme.statusSymbol(ls)))
+				}
+				cells[r] = line.String()
+			}
+			keys[j] = m.theme.newStyle().
+				Padding(0, 1).
+				Border(lipgloss.NormalBorder()).
+				BorderForeground(m.theme.Muted).
+				Foreground(m.theme.Text).
+				Align(lipgloss.Center).
+				Render(lipgloss.JoinVertical(lipgloss.Center, string(c), strings.Join(cells, "\n")))
+		}
+		rr[i] = renderRowOfBoxes(keys)
+	}
+	return lipgloss.JoinVertical(lipgloss.Center, rr...)
+}
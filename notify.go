@@ -0,0 +1,99 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// severity is how a notice is shown.
+type severity int
+
+const (
+	severityInfo severity = iota
+	severityError
+	severitySuccess
+)
+
+const (
+	// noticeDuration is how long info and success notices show for.
+	noticeDuration = 2 * time.Second
+	// errorDuration is how long errors show for.
+	errorDuration = 3 * time.Second
+)
+
+// notice is a message that shows in place of the status for a while. Notices
+// are queued, so each one gets its turn.
+type notice struct {
+	id       int
+	text     string
+	severity severity
+	duration time.Duration
+}
+
+// msgResetStatus is sent when the notice with the given id has been shown for
+// long enough.
+type msgResetStatus struct {
+	id int
+}
+
+// msgStarted is sent once the program has started, so that Update times any
+// notices from starting up like it does every other notice.
+type msgStarted struct{}
+
+// handleNotify queues a notice to show for duration.
+func (m *model) handleNotify(text string, sev severity, duration time.Duration) {
+	// the same notice twice in a row would just look stuck
+	if n := len(m.notices); n > 0 && m.notices[n-1].text == text {
+		return
+	}
+	m.noticeSeq++
+	m.notices = append(m.notices, notice{
+		id:       m.noticeSeq,
+		text:     text,
+		severity: sev,
+		duration: duration,
+	})
+}
+
+// handleError shows err as an error notice and highlights the row being
+// typed, since that's what it's usually about. If the row isn't a word,
+// words it might have been a typo for are suggested.
+func (m *model) handleError(err error) {
+	m.rowError = true
+	m.handleNotify(err.Error(), severityError, errorDuration)
+	m.handleSuggest(err)
+}
+
+// handleNoticeTimer returns a tea.Cmd that times the notice that's showing,
+// unless its timer is already running.
+func (m *model) handleNoticeTimer() tea.Cmd {
+	if len(m.notices) == 0 || m.notices[0].id == m.noticeTimer {
+		return nil
+	}
+	n := m.notices[0]
+	m.noticeTimer = n.id
+	return tea.Tick(n.duration, func(time.Time) tea.Msg {
+		return msgResetStatus{id: n.id}
+	})
+}
+
+// handleResetNotice takes the notice that's showing off the screen, if it's
+// the one whose time is up.
+func (m *model) handleResetNotice(msg msgResetStatus) {
+	// the notices may have been cleared since the timer started
+	if len(m.notices) == 0 || m.notices[0].id != msg.id {
+		return
+	}
+	if m.notices[0].severity == severityError {
+		m.rowError = false
+	}
+	m.notices = m.notices[1:]
+}
+
+// handleClearNotices drops every notice, for when the status has something
+// more important to say.
+func (m *model) handleClearNotices() {
+	m.notices = nil
+	m.rowError = false
+}
@@ -0,0 +1,403 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bianxm/godle/race"
+	"github.com/bianxm/godle/wordle"
+	"github.com/bianxm/godle/words"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func init() {
+	servers["race"] = runServeRace
+}
+
+// raceState is kept by the model in race mode.
+type raceState struct {
+	client *race.Client
+	room   string
+	// you is the player's ID in the room, and players is everyone in it.
+	you     int
+	players []race.Player
+	// boards has the colours of every guess each player has made this race,
+	// by player ID.
+	boards map[int][][]wordle.LetterStatus
+	// racing is set while a race is on, and waiting while the player's guess
+	// is with the server.
+	racing  bool
+	waiting bool
+	// result is how the last race finished, and closed is set once the
+	// server has gone away.
+	result *race.Message
+	closed bool
+}
+
+// msgRace is a message from the race server.
+type msgRace race.Message
+
+// msgRaceClosed is sent once the connection to the race server is closed.
+type msgRaceClosed struct {
+	err error
+}
+
+// waitForRace returns a tea.Cmd that waits for the next message from the race
+// server.
+func waitForRace(client *race.Client) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-client.Messages()
+		if !ok {
+			return msgRaceClosed{client.Err()}
+		}
+		return msgRace(msg)
+	}
+}
+
+// handleStartRace switches to race mode, in the room client has joined. There's
+// nothing to play until someone starts a race.
+func (m *model) handleStartRace(client *race.Client, room string) {
+	m.mode = modeRace
+	m.daily = false
+	m.savePath = ""
+	m.race = &raceState{client: client, room: room, boards: make(map[int][][]wordle.LetterStatus)}
+	ws := wordle.NewAssistantState(m.rules)
+	m.handleNewState(&ws)
+	m.handleResetActiveGuess()
+	m.cursor = -1
+	m.gameOver = true
+	m.handleSetStatus("Joining " + room + "...")
+}
+
+// handleRaceMsg acts on a message from the race server, then waits for the
+// next one.
+func (m *model) handleRaceMsg(msg msgRace) tea.Cmd {
+	r := m.race
+	switch msg.Type {
+	case race.TypeLobby:
+		r.you = msg.You
+		r.players = msg.Players
+		if !r.racing {
+			m.handleSetStatus(m.lobbyStatus())
+		}
+	case race.TypeStarted:
+		m.handleRaceStarted(race.Message(msg))
+		// the race may be with words of another length
+		return tea.Batch(waitForRace(r.client), prepareSuggestions(m.dict))
+	case race.TypeGuess:
+		m.handleRaceGuess(race.Message(msg))
+	case race.TypeFinished:
+		m.handleRaceFinished(race.Message(msg))
+	case race.TypeError:
+		// the server turned down whatever was sent last
+		if r.waiting {
+			r.waiting = false
+			m.handleStartAnimation(animShake, m.ws.CurrGuess)
+		}
+		m.handleError(errors.New(msg.Error))
+	}
+	return waitForRace(r.client)
+}
+
+// lobbyStatus is the status while waiting for a race to start.
+func (m *model) lobbyStatus() string {
+	restart := "Press ENTER to start a race"
+	if m.race.result != nil {
+		restart = "Press ENTER to race again"
+	}
+	if len(m.race.players) < race.MinPlayers {
+		return "Waiting for someone else to join " + m.race.room + "..."
+	}
+	return fmt.Sprintf("%d players in %s\n%s", len(m.race.players), m.race.room, restart)
+}
+
+func (m *model) handleRaceStarted(msg race.Message) {
+	rules := wordle.Rules{WordSize: msg.Length, MaxGuesses: msg.MaxGuesses, HardMode: msg.Hard}
+	if rules.WordSize != m.rules.WordSize {
+		dict, err := words.Builtin(rules.WordSize)
+		if err != nil {
+			m.handleError(fmt.Errorf("Can't race with %d letter words", rules.WordSize))
+			return
+		}
+		m.dict = dict
+		m.activeGuess = make([]byte, rules.WordSize)
+	}
+	m.rules = rules
+	// the server knows the word, so guesses are scored like in assistant mode
+	ws := wordle.NewAssistantState(rules)
+	m.handleNewState(&ws)
+
+	r := m.race
+	r.players = msg.Players
+	r.boards = make(map[int][][]wordle.LetterStatus)
+	r.racing = true
+	r.waiting = false
+	r.result = nil
+	m.gameOver = false
+	m.handleResetActiveGuess()
+	m.handleSetStatus("Go!")
+}
+
+// handleRaceGuess plays the player's own guess once the server has scored it,
+// or adds someone else's to their board.
+func (m *model) handleRaceGuess(msg race.Message) {
+	r := m.race
+	r.boards[msg.Player] = append(r.boards[msg.Player], msg.Statuses)
+	if msg.Player != r.you {
+		return
+	}
+	r.waiting = false
+	g := wordle.NewGuess(msg.Word)
+	for i := range g {
+		g[i].Status = msg.Statuses[i]
+	}
+	if err := m.ws.AppendGuess(g); err != nil {
+		m.handleError(err)
+		return
+	}
+	m.handleStartAnimation(animFlip, m.ws.CurrGuess-1)
+	m.handleResetActiveGuess()
+	if !m.ws.ShouldEndGame() {
+		m.handleResetStatus()
+		return
+	}
+	m.gameOver = true
+	m.cursor = -1
+	if m.ws.IsWordGuessed() {
+		m.handleSetStatus(fmt.Sprintf("Solved in %d! Waiting for the others...", m.ws.CurrGuess))
+	} else {
+		m.handleSetStatus("Out of guesses :( Waiting for the others...")
+	}
+}
+
+func (m *model) handleRaceFinished(msg race.Message) {
+	r := m.race
+	r.racing = false
+	r.waiting = false
+	r.result = &msg
+	m.gameOver = true
+	m.cursor = -1
+
+	winner := "Nobody got it"
+	if len(msg.Standings) > 0 && msg.Standings[0].Solved {
+		if msg.Standings[0].ID == r.you {
+			winner = "You won!"
+		} else {
+			winner = msg.Standings[0].Name + " won!"
+		}
+	}
+	m.handleSetStatus(fmt.Sprintf("%s The word was %s\n%s", winner, msg.Word, m.lobbyStatus()))
+}
+
+// handleRaceClosed stops the race once the server has gone away.
+func (m *model) handleRaceClosed(msg msgRaceClosed) {
+	m.race.closed = true
+	m.race.racing = false
+	m.gameOver = true
+	m.cursor = -1
+	status := "Lost connection to the server"
+	if msg.err != nil {
+		status += ": " + msg.err.Error()
+	}
+	m.handleSetStatus(status + "\nPress CTRL+D to quit")
+}
+
+// handleRaceEnter starts a race from the lobby, or sends the typed guess to
+// the server to be scored.
+func (m *model) handleRaceEnter() {
+	r := m.race
+	if r.closed || r.waiting || (r.racing && m.gameOver) {
+		return
+	}
+	if !r.racing {
+		if err := r.client.Start(); err != nil {
+			m.handleError(err)
+		}
+		return
+	}
+	g := wordle.NewGuess(string(m.activeGuess[:m.cursor]))
+	// catch what can be caught here, rather than waiting on the server
+	if err := m.ws.ValidateGuess(g); err != nil {
+		m.handleError(err)
+		m.handleStartAnimation(animShake, m.ws.CurrGuess)
+		return
+	}
+	if err := r.client.Guess(g.Word()); err != nil {
+		m.handleError(err)
+		return
+	}
+	r.waiting = true
+}
+
+// opponentGap is the space between opponents' boards.
+const opponentGap = 3
+
+// renderOpponents draws everyone else's boards, with the colours of their
+// guesses but not the letters, two boards to a row.
+func (m *model) renderOpponents() string {
+	var boards []string
+	for _, p := range m.race.players {
+		if p.ID != m.race.you {
+			boards = append(boards, m.renderOpponent(p))
+		}
+	}
+	if len(boards) == 0 {
+		return ""
+	}
+	gap := strings.Repeat(" ", opponentGap)
+	var rows []string
+	for i := 0; i < len(boards); i += 2 {
+		row := boards[i]
+		if i+1 < len(boards) {
+			row = lipgloss.JoinHorizontal(lipgloss.Top, row, gap, boards[i+1])
+		}
+		if i > 0 {
+			rows = append(rows, "")
+		}
+		rows = append(rows, row)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+// renderOpponent draws one opponent's board, two characters a tile.
+func (m *model) renderOpponent(p race.Player) string {
+	guesses := m.race.boards[p.ID]
+	n := m.rules.MaxGuesses
+	if n == 0 || n < len(guesses) {
+		n = len(guesses) + 1
+	}
+	solved := false
+	lines := make([]string, n)
+	for i := range lines {
+		var b strings.Builder
+		for j := 0; j < m.rules.WordSize; j++ {
+			style := m.theme.newStyle().Foreground(m.theme.Muted)
+			symbol := m.theme.statusSymbol(wordle.None)
+			if i < len(guesses) {
+				ls := guesses[i][j]
+				style = m.theme.statusStyle(ls)
+				symbol = m.theme.statusSymbol(ls)
+			}
+			b.WriteString(style.Render(strings.Repeat(symbol, 2)))
+		}
+		lines[i] = b.String()
+	}
+	if len(guesses) > 0 {
+		solved = true
+		for _, ls := range guesses[len(guesses)-1] {
+			solved = solved && ls == wordle.Correct
+		}
+	}
+
+	name := p.Name
+	if max := 2 * m.rules.WordSize; len(name) > max {
+		name = name[:max-1] + "…"
+	}
+	if solved {
+		name += " ✓"
+	}
+	title := m.theme.newStyle().Bold(true).Foreground(m.theme.Text).Render(name)
+	return lipgloss.JoinVertical(lipgloss.Left, append([]string{title}, lines...)...)
+}
+
+// renderStandings draws how everyone did in the last race.
+func (m *model) renderStandings() string {
+	lines := make([]string, len(m.race.result.Standings))
+	for i, s := range m.race.result.Standings {
+		result := "didn't get it"
+		if s.Solved {
+			result = fmt.Sprintf("%d %s", s.Guesses, plural(s.Guesses, "guess", "guesses"))
+		}
+		name := s.Name
+		if s.ID == m.race.you {
+			name += " (you)"
+		}
+		lines[i] = fmt.Sprintf("%d. %s  %s  %s", i+1, name, result, s.Time.Round(100*time.Millisecond))
+	}
+	return m.theme.newStyle().Foreground(m.theme.Text).Render(strings.Join(lines, "\n"))
+}
+
+// runRace joins a race on a server started with "godle serve race".
+func runRace(args []string) error {
+	cfg := defaultConfig()
+	fs := newFlagSet("race", "race [flags] HOST:PORT", `Race other players to guess the same word, on a server started with
+"godle serve race". Everyone in a room gets the same word, and sees the colours
+of each other's guesses as they go. The first to solve it wins, with ties going
+to whoever used fewer guesses and then whoever was quicker.`)
+	addDisplayFlags(fs, &cfg)
+	room := fs.String("room", "lobby", "room to join")
+	name := fs.String("name", os.Getenv("USER"), "name to race as")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errUsage
+	}
+	if strings.TrimSpace(*name) == "" {
+		return errors.New("Pick a name to race as with -name")
+	}
+
+	client, err := race.Dial(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if err := client.Join(*room, *name); err != nil {
+		return err
+	}
+	cfg.mode = modeRace
+	cfg.raceClient = client
+	cfg.raceRoom = *room
+	// races aren't saved or counted in the stats
+	cfg.statsPath = ""
+	cfg.savePath = ""
+	return runTUI(cfg)
+}
+
+// runServeRace hosts rooms for race mode.
+func runServeRace(args []string) error {
+	fs := newFlagSet("serve race", "serve race [flags]", `Host races for "godle race". Players join rooms by name, and anyone in a room
+can start a race once there's someone to race against.`)
+	addr := fs.String("addr", ":7777", "address to listen on")
+	rules := wordle.DefaultRules()
+	fs.IntVar(&rules.WordSize, "length", rules.WordSize, "number of letters in the word")
+	fs.IntVar(&rules.MaxGuesses, "guesses", rules.MaxGuesses, "number of guesses allowed, or 0 for unlimited")
+	fs.BoolVar(&rules.HardMode, "hard", false, "require revealed hints to be used in later guesses")
+	seed := fs.Int64("seed", 0, "seed for picking words (default random)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return errUsage
+	}
+	if err := checkRules(rules, nil); err != nil {
+		return err
+	}
+	dict, _ := words.Builtin(rules.WordSize)
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+
+	srv, err := race.NewServer(dict, rules, *seed)
+	if err != nil {
+		return err
+	}
+	l, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Hosting races on %s\n", l.Addr())
+	err = serveUntilStopped(func() error { return srv.Serve(l) }, l.Close)
+	if errors.Is(err, net.ErrClosed) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,106 @@
+package race
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"sync"
+)
+
+// Client is a player's connection to a Server.
+type Client struct {
+	conn     net.Conn
+	messages chan Message
+
+	mu     sync.Mutex
+	enc    *json.Encoder
+	err    error
+	closed bool
+}
+
+// Dial connects to the server at addr over TCP.
+func Dial(addr string) (*Client, error) {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(conn), nil
+}
+
+// NewClient returns a client that talks to a server over conn, which can be
+// one end of a net.Pipe for a server in the same process.
+func NewClient(conn net.Conn) *Client {
+	c := &Client{
+		conn:     conn,
+		messages: make(chan Message, outbox),
+		enc:      json.NewEncoder(conn),
+	}
+	go c.read()
+	return c
+}
+
+// Messages returns the messages from the server, in the order they were
+// sent. It's closed once the connection is, after which Err says why.
+func (c *Client) Messages() <-chan Message {
+	return c.messages
+}
+
+// Err returns the error that closed the connection, or nil if it's still
+// open or it was closed on purpose.
+func (c *Client) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+func (c *Client) read() {
+	defer close(c.messages)
+	scanner := bufio.NewScanner(c.conn)
+	for scanner.Scan() {
+		var msg Message
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			c.setErr(err)
+			c.conn.Close()
+			return
+		}
+		c.messages <- msg
+	}
+	c.setErr(scanner.Err())
+}
+
+func (c *Client) setErr(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err == nil && !c.closed {
+		c.err = err
+	}
+}
+
+// Join joins the room called room as name.
+func (c *Client) Join(room, name string) error {
+	return c.send(Message{Type: TypeJoin, Room: room, Name: name})
+}
+
+// Start starts a race in the room.
+func (c *Client) Start() error {
+	return c.send(Message{Type: TypeStart})
+}
+
+// Guess plays word in the race.
+func (c *Client) Guess(word string) error {
+	return c.send(Message{Type: TypeGuess, Word: word})
+}
+
+func (c *Client) send(msg Message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.enc.Encode(msg)
+}
+
+// Close disconnects from the server.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+	return c.conn.Close()
+}
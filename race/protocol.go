@@ -0,0 +1,105 @@
+// Package race runs head-to-head races, where two or more players guess the
+// same secret word at once and see how each other are doing as they go.
+//
+// Players connect to a Server, join a room by name, and any of them can start
+// a race once there are two. The server picks the word and scores every
+// guess, so nobody's client ever knows the word before the race is over.
+// Everyone else only sees the colours of a player's guesses, not the letters.
+//
+// Clients and servers talk in Messages, one JSON object per line, over TCP or
+// anything else that carries a stream of bytes.
+package race
+
+import (
+	"sort"
+	"time"
+
+	"github.com/bianxm/godle/wordle"
+)
+
+// The types of Message. Clients send join, start and guess, and servers send
+// the rest, along with guess to tell players about guesses.
+const (
+	// TypeJoin joins the room called Room as Name.
+	TypeJoin = "join"
+	// TypeStart starts a race in the player's room.
+	TypeStart = "start"
+	// TypeGuess is a guess of Word from a client. From the server, it's the
+	// Statuses of a guess by Player, with the Word only if it's theirs.
+	TypeGuess = "guess"
+	// TypeLobby tells everyone in a room who's in it, and which player they
+	// are as You.
+	TypeLobby = "lobby"
+	// TypeStarted tells everyone in a room that a race has begun, with
+	// words of Length letters and MaxGuesses guesses each, in Hard mode if
+	// it's set.
+	TypeStarted = "started"
+	// TypeFinished tells everyone the race is over, with the Word and the
+	// Standings.
+	TypeFinished = "finished"
+	// TypeError tells a player their last message was turned down.
+	TypeError = "error"
+)
+
+// Message is anything a client or server sends. Only the fields for its Type
+// are set.
+type Message struct {
+	Type string `json:"type"`
+
+	Room string `json:"room,omitempty"`
+	Name string `json:"name,omitempty"`
+	Word string `json:"word,omitempty"`
+
+	Player   int                   `json:"player,omitempty"`
+	Statuses []wordle.LetterStatus `json:"statuses,omitempty"`
+
+	You     int      `json:"you,omitempty"`
+	Players []Player `json:"players,omitempty"`
+
+	Length     int  `json:"length,omitempty"`
+	MaxGuesses int  `json:"max_guesses,omitempty"`
+	Hard       bool `json:"hard,omitempty"`
+
+	Standings []Standing `json:"standings,omitempty"`
+	Error     string     `json:"error,omitempty"`
+}
+
+// Player is someone in a room.
+type Player struct {
+	// ID tells players apart within a room. IDs start at 1.
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// Standing is how a player did in a race.
+type Standing struct {
+	Player
+	Solved  bool `json:"solved"`
+	Guesses int  `json:"guesses"`
+	// Time is how long the player took to finish, or how long they played
+	// for if they didn't.
+	Time time.Duration `json:"time"`
+}
+
+// beats reports whether a did better than b. Solving beats not solving, and
+// between players who solved it, fewer guesses win, then less time.
+func (a Standing) beats(b Standing) bool {
+	if a.Solved != b.Solved {
+		return a.Solved
+	}
+	if !a.Solved {
+		return false
+	}
+	if a.Guesses != b.Guesses {
+		return a.Guesses < b.Guesses
+	}
+	return a.Time < b.Time
+}
+
+// rank sorts standings from first to last, keeping the order of players
+// that tied.
+func rank(standings []Standing) {
+	sort.SliceStable(standings, func(i, j int) bool {
+		return standings[i].beats(standings[j])
+	})
+}
@@ -0,0 +1,204 @@
+package race
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bianxm/godle/wordle"
+	"github.com/bianxm/godle/words"
+)
+
+// newTestServer returns a server that races on word, with a clock that
+// moves on a second every time it's read.
+func newTestServer(t *testing.T, word string) *Server {
+	t.Helper()
+	dict, err := words.New([]string{word}, []string{word, "CRANE", "PIOUS", "BLIMP", "CHARM", "CHART"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewServer(dict, wordle.DefaultRules(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(0, 0)
+	s.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+	return s
+}
+
+// expect returns the next message c gets, failing unless it's of type typ.
+func expect(t *testing.T, c *Client, typ string) Message {
+	t.Helper()
+	select {
+	case msg, ok := <-c.Messages():
+		if !ok {
+			t.Fatalf("Connection closed waiting for %s: %v", typ, c.Err())
+		}
+		if msg.Type != typ {
+			t.Fatalf("Got %+v, want a %s message", msg, typ)
+		}
+		return msg
+	case <-time.After(time.Second):
+		t.Fatalf("Timed out waiting for %s", typ)
+	}
+	return Message{}
+}
+
+// join connects a client to s as name in room, and reads the lobby messages
+// everyone gets.
+func join(t *testing.T, s *Server, room, name string, others ...*Client) *Client {
+	t.Helper()
+	c := s.Connect()
+	t.Cleanup(func() { c.Close() })
+	if err := c.Join(room, name); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, c, TypeLobby)
+	for _, other := range others {
+		expect(t, other, TypeLobby)
+	}
+	return c
+}
+
+func TestRace(t *testing.T) {
+	s := newTestServer(t, "CHARM")
+	alice := join(t, s, "room", "alice")
+	alice.Start()
+	if msg := expect(t, alice, TypeError); msg.Error == "" {
+		t.Errorf("Starting alone should be an error")
+	}
+	bob := join(t, s, "room", "bob", alice)
+	carol := join(t, s, "room", "carol", alice, bob)
+
+	bob.Start()
+	for _, c := range []*Client{alice, bob, carol} {
+		msg := expect(t, c, TypeStarted)
+		if msg.Length != 5 || msg.MaxGuesses != 6 || len(msg.Players) != 3 {
+			t.Errorf("Started = %+v, want 3 players with the standard rules", msg)
+		}
+	}
+
+	// alice's guess shows up for everyone, but only she sees the letters
+	alice.Guess("chart")
+	mine := expect(t, alice, TypeGuess)
+	theirs := expect(t, bob, TypeGuess)
+	expect(t, carol, TypeGuess)
+	want := []wordle.LetterStatus{wordle.Correct, wordle.Correct, wordle.Correct, wordle.Correct, wordle.Absent}
+	if mine.Word != "CHART" || theirs.Word != "" || theirs.Player != mine.Player {
+		t.Errorf("Guess messages = %+v and %+v, want the word only for alice", mine, theirs)
+	}
+	for i, ls := range want {
+		if theirs.Statuses[i] != ls {
+			t.Errorf("Statuses = %v, want %v", theirs.Statuses, want)
+			break
+		}
+	}
+
+	bob.Guess("HHHHH")
+	expect(t, bob, TypeError)
+
+	// carol solves in two, but bob could still solve in one, so it's not
+	// over until he can't
+	for _, word := range []string{"CRANE", "CHARM"} {
+		carol.Guess(word)
+		for _, c := range []*Client{alice, bob, carol} {
+			expect(t, c, TypeGuess)
+		}
+	}
+	bob.Guess("PIOUS")
+	for _, c := range []*Client{alice, bob, carol} {
+		expect(t, c, TypeGuess)
+	}
+	var finished Message
+	for _, c := range []*Client{alice, bob, carol} {
+		finished = expect(t, c, TypeFinished)
+	}
+	if finished.Word != "CHARM" {
+		t.Errorf("Word = %q, want CHARM", finished.Word)
+	}
+	names := []string{}
+	for _, st := range finished.Standings {
+		names = append(names, st.Name)
+	}
+	if len(names) != 3 || names[0] != "carol" || names[1] != "alice" || names[2] != "bob" {
+		t.Errorf("Standings = %v, want carol, alice, bob", names)
+	}
+
+	// the room goes back to the lobby for another race
+	alice.Start()
+	expect(t, carol, TypeStarted)
+}
+
+func TestLeaveEndsRace(t *testing.T) {
+	s := newTestServer(t, "CHARM")
+	alice := join(t, s, "room", "alice")
+	bob := join(t, s, "room", "bob", alice)
+	alice.Start()
+	expect(t, alice, TypeStarted)
+	expect(t, bob, TypeStarted)
+
+	for _, word := range []string{"CRANE", "CHARM"} {
+		alice.Guess(word)
+		expect(t, alice, TypeGuess)
+		expect(t, bob, TypeGuess)
+	}
+	// bob could still win, so the race waits for him until he leaves
+	bob.Close()
+	expect(t, alice, TypeLobby)
+	msg := expect(t, alice, TypeFinished)
+	if len(msg.Standings) != 1 || !msg.Standings[0].Solved {
+		t.Errorf("Standings = %+v, want alice winning alone", msg.Standings)
+	}
+
+	// a room that's racing can't be joined
+	carol := join(t, s, "other", "carol")
+	dave := join(t, s, "other", "dave", carol)
+	carol.Start()
+	expect(t, carol, TypeStarted)
+	expect(t, dave, TypeStarted)
+	late := s.Connect()
+	defer late.Close()
+	late.Join("other", "erin")
+	expect(t, late, TypeError)
+}
+
+func TestRank(t *testing.T) {
+	standings := []Standing{
+		{Player: Player{ID: 1}, Solved: false, Guesses: 6, Time: time.Second},
+		{Player: Player{ID: 2}, Solved: true, Guesses: 4, Time: 3 * time.Second},
+		{Player: Player{ID: 3}, Solved: true, Guesses: 3, Time: 5 * time.Second},
+		{Player: Player{ID: 4}, Solved: true, Guesses: 4, Time: 2 * time.Second},
+	}
+	rank(standings)
+	for i, id := range []int{3, 4, 2, 1} {
+		if standings[i].ID != id {
+			t.Errorf("Place %d = player %d, want %d", i+1, standings[i].ID, id)
+		}
+	}
+}
+
+func TestJoinClosingRoom(t *testing.T) {
+	s := newTestServer(t, "CHARM")
+	alice := &player{out: make(chan Message, outbox)}
+	if err := s.join(alice, "room", "alice"); err != nil {
+		t.Fatal(err)
+	}
+	closing := alice.room
+
+	// alice leaves, and bob joins before the server has forgotten the room
+	closing.mu.Lock()
+	closing.players = nil
+	closing.closed = true
+	closing.mu.Unlock()
+	bob := &player{out: make(chan Message, outbox)}
+	if err := s.join(bob, "room", "bob"); err != nil {
+		t.Fatal(err)
+	}
+	s.closeRoom(closing)
+
+	if bob.room == closing || s.rooms["room"] != bob.room {
+		t.Errorf("Bob should be in a new room that others can join, but isn't")
+	}
+}
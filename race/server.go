@@ -0,0 +1,401 @@
+package race
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bianxm/godle/wordle"
+	"github.com/bianxm/godle/words"
+)
+
+// MinPlayers is how many players a room needs to start a race.
+const MinPlayers = 2
+
+// outbox is how many messages can wait to be sent to a player. Players who
+// fall that far behind are disconnected, so they can't hold up everyone else.
+const outbox = 64
+
+// Server hosts rooms of players racing to guess words. It's safe to use from
+// several goroutines.
+type Server struct {
+	dict  *words.Dictionary
+	rules wordle.Rules
+	now   func() time.Time
+
+	mu    sync.Mutex
+	rooms map[string]*room
+	// rand picks the words.
+	rand *rand.Rand
+}
+
+// NewServer returns a server that races with words from dict under rules,
+// picking them with seed. It returns an error if the rules can't be played
+// with dict's words.
+func NewServer(dict *words.Dictionary, rules wordle.Rules, seed int64) (*Server, error) {
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+	if dict.Length() != rules.WordSize {
+		return nil, fmt.Errorf("Can't race with %d letter words from a dictionary of %d letter words", rules.WordSize, dict.Length())
+	}
+	return &Server{
+		dict:  dict,
+		rules: rules,
+		now:   time.Now,
+		rooms: make(map[string]*room),
+		rand:  rand.New(rand.NewSource(seed)),
+	}, nil
+}
+
+// Serve accepts connections on l until it's closed.
+func (s *Server) Serve(l net.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go s.ServeConn(conn)
+	}
+}
+
+// ServeConn plays a race with the client on conn, returning once it has gone
+// away. conn is closed afterwards.
+func (s *Server) ServeConn(conn net.Conn) {
+	p := &player{conn: conn, out: make(chan Message, outbox)}
+	done := make(chan struct{})
+	go p.write(done)
+	defer func() {
+		if p.room != nil {
+			p.room.leave(p)
+		}
+		close(p.out)
+		<-done
+		conn.Close()
+	}()
+
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		var msg Message
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			p.send(Message{Type: TypeError, Error: "Invalid message"})
+			continue
+		}
+		if err := s.handle(p, msg); err != nil {
+			p.send(Message{Type: TypeError, Error: err.Error()})
+		}
+	}
+}
+
+// Connect returns a client connected to s in the same process, without going
+// through the network.
+func (s *Server) Connect() *Client {
+	client, server := net.Pipe()
+	go s.ServeConn(server)
+	return NewClient(client)
+}
+
+func (s *Server) handle(p *player, msg Message) error {
+	if msg.Type == TypeJoin {
+		return s.join(p, msg.Room, msg.Name)
+	}
+	if p.room == nil {
+		return errors.New("Join a room first")
+	}
+	switch msg.Type {
+	case TypeStart:
+		return p.room.start()
+	case TypeGuess:
+		return p.room.guess(p, strings.ToUpper(msg.Word))
+	default:
+		return fmt.Errorf("Unknown message type %q", msg.Type)
+	}
+}
+
+// join puts p in the room called name, making the room if there isn't one.
+func (s *Server) join(p *player, name, playerName string) error {
+	name = strings.TrimSpace(name)
+	playerName = strings.TrimSpace(playerName)
+	if name == "" || playerName == "" {
+		return errors.New("Rooms and players need names")
+	}
+	if p.room != nil {
+		return errors.New("Already in a room")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.rooms[name]
+	if ok {
+		err := r.join(p, playerName)
+		if !errors.Is(err, errRoomClosed) {
+			return err
+		}
+		// everyone left just now, and closeRoom hasn't caught up yet
+	}
+	r = &room{server: s, name: name}
+	s.rooms[name] = r
+	return r.join(p, playerName)
+}
+
+// pickWord returns the next word to race on.
+func (s *Server) pickWord() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dict.Random(s.rand)
+}
+
+// errRoomClosed is returned by room.join once everyone has left the room.
+var errRoomClosed = errors.New("That room has closed")
+
+// closeRoom forgets r once everyone has left it.
+func (s *Server) closeRoom(r *room) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.rooms[r.name] == r {
+		delete(s.rooms, r.name)
+	}
+}
+
+// player is a client connected to the server.
+type player struct {
+	Player
+	conn net.Conn
+	out  chan Message
+	// room is the room the player's in. It's only used by the goroutine
+	// reading the player's messages.
+	room *room
+
+	// these are looked after by the room's lock
+	ws       *wordle.WordleState
+	finished time.Time
+
+	// mu guards dropped, which is set once the player has been disconnected
+	// for falling behind.
+	mu      sync.Mutex
+	dropped bool
+}
+
+// send queues msg to be sent to p, disconnecting p if too much is queued
+// already.
+func (p *player) send(msg Message) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.dropped {
+		return
+	}
+	select {
+	case p.out <- msg:
+	default:
+		// the reading goroutine notices and leaves the room
+		p.dropped = true
+		p.conn.Close()
+	}
+}
+
+// write sends p's messages until its outbox is closed, then closes done.
+func (p *player) write(done chan<- struct{}) {
+	defer close(done)
+	enc := json.NewEncoder(p.conn)
+	for msg := range p.out {
+		if err := enc.Encode(msg); err != nil {
+			p.conn.Close()
+			// keep draining so that senders never block
+		}
+	}
+}
+
+// room is a group of players who race each other. Everything in a room
+// happens under its lock, so messages go out in the order things happened.
+type room struct {
+	server *Server
+	name   string
+
+	mu      sync.Mutex
+	players []*player
+	nextID  int
+	// word is the word being raced on, and racing is set while the race is
+	// on.
+	word    string
+	racing  bool
+	started time.Time
+	// closed is set once everyone has left, after which nobody can join.
+	closed bool
+}
+
+// join adds p to the room as name. The server's lock is held.
+func (r *room) join(p *player, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return errRoomClosed
+	}
+	if r.racing {
+		return errors.New("That room is in the middle of a race")
+	}
+	r.nextID++
+	p.Player = Player{ID: r.nextID, Name: name}
+	p.room = r
+	r.players = append(r.players, p)
+	r.broadcastLobby()
+	return nil
+}
+
+// leave takes p out of the room, which may finish the race if everyone left
+// is done.
+func (r *room) leave(p *player) {
+	r.mu.Lock()
+	for i, other := range r.players {
+		if other == p {
+			r.players = append(r.players[:i], r.players[i+1:]...)
+			break
+		}
+	}
+	empty := len(r.players) == 0
+	r.closed = empty
+	if !empty {
+		r.broadcastLobby()
+		if r.racing {
+			r.checkFinished()
+		}
+	}
+	r.mu.Unlock()
+	if empty {
+		r.server.closeRoom(r)
+	}
+}
+
+// start starts a race with a new word.
+func (r *room) start() error {
+	word := r.server.pickWord()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.racing {
+		return errors.New("The race has already started")
+	}
+	if len(r.players) < MinPlayers {
+		return fmt.Errorf("Races need at least %d players", MinPlayers)
+	}
+	r.word = word
+	r.racing = true
+	r.started = r.server.now()
+	for _, p := range r.players {
+		ws := wordle.NewWordleState(word, r.server.rules)
+		ws.Dict = r.server.dict
+		p.ws = &ws
+		p.finished = time.Time{}
+	}
+	r.broadcast(Message{
+		Type:       TypeStarted,
+		Length:     r.server.rules.WordSize,
+		MaxGuesses: r.server.rules.MaxGuesses,
+		Hard:       r.server.rules.HardMode,
+		Players:    r.playerList(),
+	})
+	return nil
+}
+
+// guess plays word for p and tells everyone how it did.
+func (r *room) guess(p *player, word string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.racing || p.ws == nil {
+		return errors.New("The race hasn't started")
+	}
+	if p.ws.ShouldEndGame() {
+		return errors.New("You've finished this race")
+	}
+	g := wordle.NewGuess(word)
+	if err := p.ws.ValidateGuess(g); err != nil {
+		return err
+	}
+	p.ws.Score(g)
+	if err := p.ws.AppendGuess(g); err != nil {
+		return err
+	}
+	if p.ws.ShouldEndGame() {
+		p.finished = r.server.now()
+	}
+
+	statuses := make([]wordle.LetterStatus, len(g))
+	for i, l := range g {
+		statuses[i] = l.Status
+	}
+	for _, other := range r.players {
+		msg := Message{Type: TypeGuess, Player: p.ID, Statuses: statuses}
+		if other == p {
+			msg.Word = g.Word()
+		}
+		other.send(msg)
+	}
+	r.checkFinished()
+	return nil
+}
+
+// checkFinished ends the race once nobody who's still playing can do better
+// than the best player who's done. r.mu must be held.
+func (r *room) checkFinished() {
+	standings := r.standings()
+	best := standings[0]
+	for _, p := range r.players {
+		if p.ws.ShouldEndGame() {
+			continue
+		}
+		// solving with the next guess would win unless it ties on guesses,
+		// since the best player got there first
+		if !best.Solved || p.ws.CurrGuess+1 < best.Guesses {
+			return
+		}
+	}
+	r.racing = false
+	r.broadcast(Message{Type: TypeFinished, Word: r.word, Standings: standings})
+}
+
+// standings returns how everyone in the room is doing, best first. r.mu must
+// be held.
+func (r *room) standings() []Standing {
+	now := r.server.now()
+	standings := make([]Standing, len(r.players))
+	for i, p := range r.players {
+		end := p.finished
+		if end.IsZero() {
+			end = now
+		}
+		standings[i] = Standing{
+			Player:  p.Player,
+			Solved:  p.ws.IsWordGuessed(),
+			Guesses: p.ws.CurrGuess,
+			Time:    end.Sub(r.started),
+		}
+	}
+	rank(standings)
+	return standings
+}
+
+// broadcastLobby tells everyone who's in the room. r.mu must be held.
+func (r *room) broadcastLobby() {
+	players := r.playerList()
+	for _, p := range r.players {
+		p.send(Message{Type: TypeLobby, Room: r.name, You: p.ID, Players: players})
+	}
+}
+
+// broadcast sends msg to everyone in the room. r.mu must be held.
+func (r *room) broadcast(msg Message) {
+	for _, p := range r.players {
+		p.send(msg)
+	}
+}
+
+func (r *room) playerList() []Player {
+	list := make([]Player, len(r.players))
+	for i, p := range r.players {
+		list[i] = p.Player
+	}
+	return list
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bianxm/godle/race"
+	"github.com/bianxm/godle/wordle"
+	"github.com/bianxm/godle/words"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// receive passes the next message from the race server to m.
+func receive(t *testing.T, m model) model {
+	t.Helper()
+	msgs := make(chan tea.Msg, 1)
+	go func() { msgs <- waitForRace(m.race.client)() }()
+	select {
+	case msg := <-msgs:
+		m, _ = update(m, msg)
+		return m
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for the race server")
+	}
+	return m
+}
+
+func TestRaceMode(t *testing.T) {
+	dict, err := words.New([]string{"CRANE"}, []string{"CRANE", "SLATE"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv, err := race.NewServer(dict, wordle.DefaultRules(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := srv.Connect()
+	defer client.Close()
+	if err := client.Join("room", "alice"); err != nil {
+		t.Fatal(err)
+	}
+	m := newTestModel("CRANE", func(cfg *config) {
+		cfg.mode = modeRace
+		cfg.raceClient = client
+		cfg.raceRoom = "room"
+	})
+
+	m = receive(t, m)
+	if !strings.Contains(m.status, "Waiting for someone else") {
+		t.Errorf("Status alone in the room is %q, want waiting", m.status)
+	}
+	other := srv.Connect()
+	defer other.Close()
+	if err := other.Join("room", "bob"); err != nil {
+		t.Fatal(err)
+	}
+	m = receive(t, m)
+	if !strings.Contains(m.status, "Press ENTER to start") {
+		t.Errorf("Status with two players is %q, want a prompt to start", m.status)
+	}
+
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = receive(t, m)
+	if m.gameOver || !m.race.racing {
+		t.Fatal("Race should have started, but hasn't")
+	}
+
+	if err := other.Guess("SLATE"); err != nil {
+		t.Fatal(err)
+	}
+	m = receive(t, m)
+	if len(m.race.boards[2]) != 1 || m.ws.CurrGuess != 0 {
+		t.Errorf("Opponent's guess should only be on their board, but boards = %v and CurrGuess = %d", m.race.boards, m.ws.CurrGuess)
+	}
+	if panel := m.renderOpponents(); !strings.Contains(panel, "bob") || strings.Contains(panel, "S") {
+		t.Errorf("Opponents panel should show bob's colours without letters, but is\n%s", panel)
+	}
+
+	// words the client knows are wrong never get to the server
+	m, _ = submit(m, "ABCDE")
+	if m.race.waiting || len(m.notices) == 0 || m.notices[0].text != "Invalid word" {
+		t.Errorf("Invalid word should be caught locally, but waiting = %v and notices = %+v", m.race.waiting, m.notices)
+	}
+	for i := 0; i < 5; i++ {
+		m, _ = update(m, tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+
+	m, _ = submit(m, "CRANE")
+	if !m.race.waiting {
+		t.Fatal("Guess should be waiting on the server, but isn't")
+	}
+	m = receive(t, m)
+	if !m.ws.IsWordGuessed() || !m.gameOver {
+		t.Errorf("Own guess should have solved the word, but guesses = %v", m.ws.Guesses)
+	}
+	m = receive(t, m)
+	if m.race.racing || !strings.Contains(m.status, "You won!") {
+		t.Errorf("Race should be over with a win, but status is %q", m.status)
+	}
+	if hint := m.renderHint(); !strings.Contains(hint, "1. alice (you)") {
+		t.Errorf("Standings should show alice first, but are\n%s", hint)
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bianxm/godle/solver"
+	"github.com/bianxm/godle/wordle"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// reverseDelay is how long the computer waits between guesses it scores
+// itself, so the player can follow along.
+const reverseDelay = 700 * time.Millisecond
+
+const choosingStatus = "Type a word for me to guess, or just press ENTER to keep it secret"
+
+// handleStartReverse switches to reverse mode, where the player picks a word
+// and the solver guesses it.
+func (m *model) handleStartReverse() {
+	m.mode = modeReverse
+	m.daily = false
+	m.savePath = ""
+	m.gameOver = false
+	m.marking = false
+	m.handleResetWordleState()
+	m.handleResetActiveGuess()
+	m.choosing = true
+	m.handleResetStatus()
+}
+
+// handleChooseWord starts the computer guessing, either the word the player
+// typed or, if they didn't type one, a word they keep to themselves and score
+// guesses for.
+func (m *model) handleChooseWord() tea.Cmd {
+	var ws wordle.WordleState
+	if m.cursor == 0 {
+		ws = wordle.NewAssistantState(m.rules)
+	} else {
+		word := string(m.activeGuess[:m.cursor])
+		if len(word) != m.rules.WordSize {
+			m.handleError(errors.New("Invalid word length"))
+			return nil
+		}
+		if !m.dict.Contains(word) {
+			m.handleError(errors.New("I don't know that word"))
+			return nil
+		}
+		ws = wordle.NewWordleState(word, m.rules)
+	}
+	m.handleNewState(&ws)
+	m.choosing = false
+	m.handleResetActiveGuess()
+	return m.handleComputerTurn(0)
+}
+
+// handleComputerTurn returns a tea.Cmd that works out the computer's next
+// guess after waiting for delay.
+func (m *model) handleComputerTurn(delay time.Duration) tea.Cmd {
+	m.handleSetStatus("Thinking...")
+
+	// the solver gets its own copy, since the game carries on while it runs
+	ws := *m.ws
+	ws.Guesses = append([]wordle.Guess(nil), ws.Guesses...)
+	game := m.ws
+	return func() tea.Msg {
+		time.Sleep(delay)
+		word, remaining, err := solver.NextGuess(&ws, solver.MaxEntropy)
+		return msgComputerGuess{
+			ws:        game,
+			word:      word,
+			remaining: remaining,
+			err:       err,
+		}
+	}
+}
+
+// handleComputerGuess plays the computer's guess. Guesses at a known word are
+// scored straight away, otherwise the player marks them.
+func (m *model) handleComputerGuess(msg msgComputerGuess) tea.Cmd {
+	var ce *solver.ContradictionError
+	if errors.As(msg.err, &ce) {
+		m.gameOver = true
+		m.handleSetStatus(fmt.Sprintf(
+			"That can't be right: no word fits your colours for guess #%d (%s)\nPress ENTER to play again",
+			ce.Guess+1,
+			ce.Word,
+		))
+		return nil
+	} else if msg.err != nil {
+		m.gameOver = true
+		m.handleSetStatus(msg.err.Error() + "\nPress ENTER to play again")
+		return nil
+	}
+	m.reverseRemaining = msg.remaining
+
+	g := wordle.NewGuess(msg.word)
+	if m.ws.Answerer == nil {
+		for i := range g {
+			g[i].Status = wordle.Absent
+		}
+		m.marking = true
+		m.markGuess = g
+		m.markCursor = 0
+		m.handleSetStatus(markingStatus)
+		return nil
+	}
+
+	m.ws.Score(g)
+	if err := m.ws.AppendGuess(g); err != nil {
+		m.gameOver = true
+		m.handleSetStatus(err.Error() + "\nPress ENTER to play again")
+		return nil
+	}
+	m.handleShouldEndGame()
+	if m.gameOver {
+		return nil
+	}
+	return m.handleComputerTurn(reverseDelay)
+}
+
+// msgComputerGuess is sent when the solver has picked its next guess in
+// reverse mode for the game ws.
+type msgComputerGuess struct {
+	ws        *wordle.WordleState
+	word      string
+	remaining int
+	err       error
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/bianxm/godle/wordle"
+	"github.com/bianxm/godle/xdg"
+)
+
+// defaultSavePath returns where the current game is autosaved.
+func defaultSavePath() (string, error) {
+	return xdg.DataFile("game.json")
+}
+
+// dailySavePath returns where the daily puzzle is autosaved, next to the
+// free play game at savePath, so that playing on after it can't overwrite it.
+func dailySavePath(savePath string) string {
+	return filepath.Join(filepath.Dir(savePath), "daily.json")
+}
+
+// lastSavePath returns whichever of the free play game at savePath and the
+// daily puzzle next to it was saved last.
+func lastSavePath(savePath string) string {
+	daily := dailySavePath(savePath)
+	free, err := os.Stat(savePath)
+	if err != nil {
+		return daily
+	}
+	if d, err := os.Stat(daily); err == nil && d.ModTime().After(free.ModTime()) {
+		return daily
+	}
+	return savePath
+}
+
+// savedGame is a game as it's autosaved, along with what kind of game it is,
+// so that it's only resumed as the same kind of game.
+type savedGame struct {
+	// Mode is the name of the game mode, as -mode takes it.
+	Mode string
+	Game *wordle.WordleState
+}
+
+// saveGame writes saved to path, creating its directory if needed.
+func saveGame(path string, saved savedGame) error {
+	b, err := json.MarshalIndent(saved, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	// write to a temporary file first so a crash can't leave half a game
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// loadGame reads a saved game from path. It returns nil without an error if
+// there is no saved game.
+func loadGame(path string) (*savedGame, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var saved struct {
+		savedGame
+		Game json.RawMessage
+	}
+	if err := json.Unmarshal(b, &saved); err != nil {
+		return nil, errors.New("Corrupt saved game " + path + ": " + err.Error())
+	}
+	if saved.Game == nil {
+		// saves from before the mode was kept are just the game, which was
+		// always classic
+		saved.Mode = modeName(modeClassic)
+		saved.Game = b
+	}
+	var ws wordle.WordleState
+	if err := json.Unmarshal(saved.Game, &ws); err != nil {
+		return nil, errors.New("Corrupt saved game " + path + ": " + err.Error())
+	}
+	return &savedGame{Mode: saved.Mode, Game: &ws}, nil
+}
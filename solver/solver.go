@@ -0,0 +1,248 @@
+// Package solver narrows down the possible answers of a game and suggests
+// the guesses that narrow them down the most.
+package solver
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/bianxm/godle/wordle"
+)
+
+// Strategy decides how guesses are ranked.
+type Strategy int
+
+const (
+	// MaxEntropy prefers the guess whose feedback is expected to reveal the
+	// most information.
+	MaxEntropy Strategy = iota
+	// MinExpectedRemaining prefers the guess that is expected to leave the
+	// fewest candidates.
+	MinExpectedRemaining
+)
+
+// Suggestion is a ranked guess.
+type Suggestion struct {
+	Word string
+	// Entropy is the expected information from the guess's feedback, in bits.
+	Entropy float64
+	// ExpectedRemaining is the expected number of candidates left after the
+	// guess.
+	ExpectedRemaining float64
+	// Candidate is set if the guess could be the answer itself.
+	Candidate bool
+}
+
+// Consistent reports whether word could be the answer, given the feedback g
+// got.
+func Consistent(word string, g wordle.Guess) bool {
+	if len(word) != len(g) {
+		return false
+	}
+	scored := wordle.NewGuess(g.Word())
+	scored.UpdateLettersWithWord([]byte(word))
+	for i := range g {
+		if scored[i].Status != g[i].Status {
+			return false
+		}
+	}
+	return true
+}
+
+// Candidates returns the words in answers that are consistent with the
+// feedback of every guess.
+func Candidates(answers []string, guesses []wordle.Guess) []string {
+	var candidates []string
+	for _, word := range answers {
+		ok := true
+		for _, g := range guesses {
+			if !Consistent(word, g) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			candidates = append(candidates, word)
+		}
+	}
+	return candidates
+}
+
+// pattern packs the feedback of a scored guess into a number, counting each
+// letter's status as a base 3 digit.
+func pattern(g wordle.Guess) int {
+	p := 0
+	for _, l := range g {
+		p *= 3
+		switch l.Status {
+		case wordle.Present:
+			p++
+		case wordle.Correct:
+			p += 2
+		}
+	}
+	return p
+}
+
+// Partition groups the candidates by the feedback guess would get if each of
+// them were the answer.
+func Partition(guess string, candidates []string) map[int][]string {
+	buckets := make(map[int][]string)
+	g := wordle.NewGuess(guess)
+	for _, word := range candidates {
+		g.UpdateLettersWithWord([]byte(word))
+		p := pattern(g)
+		buckets[p] = append(buckets[p], word)
+	}
+	return buckets
+}
+
+// score rates guess against the candidates. counts is scratch space with room
+// for every pattern.
+func score(guess string, candidates [][]byte, isCandidate map[string]bool, counts []int) Suggestion {
+	for i := range counts {
+		counts[i] = 0
+	}
+	g := wordle.NewGuess(guess)
+	for _, word := range candidates {
+		g.UpdateLettersWithWord(word)
+		counts[pattern(g)]++
+	}
+
+	n := float64(len(candidates))
+	s := Suggestion{Word: guess, Candidate: isCandidate[guess]}
+	for _, c := range counts {
+		if c == 0 {
+			continue
+		}
+		p := float64(c) / n
+		s.Entropy -= p * math.Log2(p)
+		s.ExpectedRemaining += float64(c) * p
+	}
+	return s
+}
+
+// Rank scores every guess against the candidates and sorts them best first.
+// Ties go to guesses that could be the answer, then alphabetical order.
+func Rank(guesses, candidates []string, strategy Strategy) []Suggestion {
+	if len(candidates) == 0 {
+		return nil
+	}
+	isCandidate := make(map[string]bool, len(candidates))
+	candidateBytes := make([][]byte, len(candidates))
+	for i, word := range candidates {
+		isCandidate[word] = true
+		candidateBytes[i] = []byte(word)
+	}
+	patterns := 1
+	for range candidates[0] {
+		patterns *= 3
+	}
+
+	// scoring is independent for each guess, so spread it over every CPU
+	suggestions := make([]Suggestion, len(guesses))
+	workers := runtime.NumCPU()
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			counts := make([]int, patterns)
+			for i := w; i < len(guesses); i += workers {
+				suggestions[i] = score(guesses[i], candidateBytes, isCandidate, counts)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if strategy == MaxEntropy && a.Entropy != b.Entropy {
+			return a.Entropy > b.Entropy
+		}
+		if a.ExpectedRemaining != b.ExpectedRemaining {
+			return a.ExpectedRemaining < b.ExpectedRemaining
+		}
+		if a.Candidate != b.Candidate {
+			return a.Candidate
+		}
+		return a.Word < b.Word
+	})
+	return suggestions
+}
+
+// Suggest returns the n best next guesses for ws and the number of answers
+// still possible. Guesses come from every valid word, except in hard mode,
+// where they come from the remaining candidates so that every hint is used.
+func Suggest(ws *wordle.WordleState, n int, strategy Strategy) ([]Suggestion, int) {
+	dict := ws.Dictionary()
+	candidates := Candidates(dict.Answers(), ws.Guesses[:ws.CurrGuess])
+
+	var suggestions []Suggestion
+	switch {
+	case len(candidates) <= 2:
+		// any candidate is as good as any other guess, and might win
+		suggestions = Rank(candidates, candidates, strategy)
+	case ws.Rules.HardMode:
+		suggestions = Rank(candidates, candidates, strategy)
+	default:
+		suggestions = Rank(dict.Guesses(), candidates, strategy)
+	}
+
+	if len(suggestions) > n {
+		suggestions = suggestions[:n]
+	}
+	return suggestions, len(candidates)
+}
+
+// ContradictionError is returned when no valid word fits the feedback that
+// the guesses got, which means some of the feedback is wrong.
+type ContradictionError struct {
+	// Guess is the index of the first guess whose feedback can't be right
+	// together with the feedback of the guesses before it.
+	Guess int
+	Word  string
+}
+
+func (e *ContradictionError) Error() string {
+	return fmt.Sprintf("No word fits the feedback for guess #%d (%s)", e.Guess+1, e.Word)
+}
+
+// FindContradiction returns the first guess after which none of words fit the
+// feedback so far, or nil if some word fits all of it.
+func FindContradiction(words []string, guesses []wordle.Guess) *ContradictionError {
+	candidates := words
+	for i, g := range guesses {
+		candidates = Candidates(candidates, []wordle.Guess{g})
+		if len(candidates) == 0 {
+			return &ContradictionError{Guess: i, Word: g.Word()}
+		}
+	}
+	return nil
+}
+
+// NextGuess picks the guess the solver would make next in ws, and returns it
+// with the number of answers still possible. It assumes the answer is a
+// common word until none of them fit, and then looks at every valid word. If
+// no valid word fits, it returns a *ContradictionError.
+func NextGuess(ws *wordle.WordleState, strategy Strategy) (string, int, error) {
+	dict := ws.Dictionary()
+	guesses := ws.Guesses[:ws.CurrGuess]
+
+	if candidates := Candidates(dict.Answers(), guesses); len(candidates) > 0 {
+		suggestions, remaining := Suggest(ws, 1, strategy)
+		return suggestions[0].Word, remaining, nil
+	}
+
+	all := dict.Guesses()
+	if err := FindContradiction(all, guesses); err != nil {
+		return "", 0, err
+	}
+	candidates := Candidates(all, guesses)
+	// rare words are a long shot, so only guess words that could win
+	suggestions := Rank(candidates, candidates, strategy)
+	return suggestions[0].Word, len(candidates), nil
+}
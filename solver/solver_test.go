@@ -0,0 +1,126 @@
+package solver
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/bianxm/godle/wordle"
+)
+
+func scoredGuess(guess, answer string) wordle.Guess {
+	g := wordle.NewGuess(guess)
+	g.UpdateLettersWithWord([]byte(answer))
+	return g
+}
+
+func TestCandidates(t *testing.T) {
+	answers := []string{"CHARM", "CHART", "CHAIR", "SHARP", "TRAIN"}
+	guesses := []wordle.Guess{scoredGuess("TRAIN", "CHARM")}
+
+	got := Candidates(answers, guesses)
+	want := []string{"CHARM", "SHARP"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Candidates() = %v, want %v", got, want)
+	}
+
+	got = Candidates(answers, nil)
+	if !reflect.DeepEqual(got, answers) {
+		t.Errorf("Candidates() with no guesses = %v, want %v", got, answers)
+	}
+}
+
+func TestConsistentRepeatedLetters(t *testing.T) {
+	g := scoredGuess("LELOL", "HELLO")
+	if !Consistent("HELLO", g) {
+		t.Errorf("HELLO should be consistent with its own feedback")
+	}
+	if Consistent("HELPS", g) {
+		t.Errorf("HELPS shouldn't be consistent with %+v", g)
+	}
+}
+
+func TestRank(t *testing.T) {
+	candidates := []string{"BATCH", "CATCH", "HATCH", "LATCH", "MATCH", "PATCH"}
+	guesses := append([]string{"CHAMP", "BLIMP", "BOXER"}, candidates...)
+
+	for _, strategy := range []Strategy{MaxEntropy, MinExpectedRemaining} {
+		ranked := Rank(guesses, candidates, strategy)
+		if len(ranked) != len(guesses) {
+			t.Fatalf("Rank() returned %d suggestions, want %d", len(ranked), len(guesses))
+		}
+		// BLIMP and CHAMP each tell four candidates apart, so they tie and
+		// are sorted alphabetically
+		if ranked[0].Word != "BLIMP" || ranked[1].Word != "CHAMP" {
+			t.Errorf("Rank(%d) best guesses = %s, %s; want BLIMP, CHAMP", strategy, ranked[0].Word, ranked[1].Word)
+		}
+		// BOXER only tells BATCH apart, like every candidate does, so it
+		// should come after all of them
+		if last := ranked[len(ranked)-1]; last.Word != "BOXER" {
+			t.Errorf("Rank(%d) worst guess = %s, want BOXER", strategy, last.Word)
+		}
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	ws := wordle.NewWordleState("CHARM", wordle.DefaultRules())
+	for _, word := range []string{"TRAIN", "SHARP"} {
+		if err := ws.AppendGuess(scoredGuess(word, "CHARM")); err != nil {
+			t.Fatalf("Error: %s", err)
+		}
+	}
+
+	suggestions, remaining := Suggest(&ws, 3, MaxEntropy)
+	if remaining == 0 {
+		t.Fatalf("No candidates left, but CHARM should be one")
+	}
+	if len(suggestions) == 0 || len(suggestions) > 3 {
+		t.Errorf("Suggest() returned %d suggestions, want 1 to 3", len(suggestions))
+	}
+	t.Logf("%d remaining, suggestions %+v", remaining, suggestions)
+}
+
+func TestFindContradiction(t *testing.T) {
+	answers := []string{"CHARM", "CHART", "SHARP", "TRAIN"}
+	guesses := []wordle.Guess{
+		scoredGuess("TRAIN", "CHARM"),
+		scoredGuess("SHARP", "CHARM"),
+		// CHARM can't be all wrong after SHARP found H, A and R
+		scoredGuess("CHARM", "BOXED"),
+	}
+
+	if err := FindContradiction(answers, guesses[:2]); err != nil {
+		t.Errorf("FindContradiction() = %s, want nil", err)
+	}
+	err := FindContradiction(answers, guesses)
+	if err == nil {
+		t.Fatalf("FindContradiction() = nil, want guess 3")
+	}
+	if err.Guess != 2 || err.Word != "CHARM" {
+		t.Errorf("FindContradiction() = %+v, want guess 2 CHARM", err)
+	}
+}
+
+func TestNextGuessContradiction(t *testing.T) {
+	ws := wordle.NewAssistantState(wordle.DefaultRules())
+	for _, word := range []string{"TRAIN", "TRAIN"} {
+		g := wordle.NewGuess(word)
+		g.UpdateLettersWithWord([]byte("CHARM"))
+		if ws.CurrGuess == 1 {
+			// the same guess can't get different feedback
+			g.UpdateLettersWithWord([]byte("BOXED"))
+		}
+		if err := ws.AppendGuess(g); err != nil {
+			t.Fatalf("Error: %s", err)
+		}
+	}
+
+	_, _, err := NextGuess(&ws, MaxEntropy)
+	var ce *ContradictionError
+	if !errors.As(err, &ce) {
+		t.Fatalf("NextGuess() = %v, want ContradictionError", err)
+	}
+	if ce.Guess != 1 {
+		t.Errorf("Contradiction at guess %d, want 1", ce.Guess)
+	}
+}
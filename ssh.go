@@ -0,0 +1,189 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bianxm/godle/wordle"
+	"github.com/bianxm/godle/words"
+	"github.com/bianxm/godle/xdg"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	"github.com/charmbracelet/wish/activeterm"
+	bm "github.com/charmbracelet/wish/bubbletea"
+	"github.com/charmbracelet/wish/logging"
+	"github.com/muesli/termenv"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+func init() {
+	servers["ssh"] = runServeSSH
+}
+
+// runServeSSH hosts godle over SSH, so people can play without installing it.
+func runServeSSH(args []string) error {
+	fs := newFlagSet("serve ssh", "serve ssh [flags]", `Host godle over SSH. Everyone gets the same daily puzzle, then free play.
+Players who log in with a public key keep their own stats and saved game.
+Players can pick game flags after the host, like: ssh -t HOST -- -hard`)
+	addr := fs.String("addr", ":23234", "address to listen on")
+	hostKey := fs.String("host-key", "", "host key file, created if it doesn't exist (default ssh/host_ed25519 in godle's data directory)")
+	salt := fs.String("salt", "", "secret that changes which word each day gets, so the answers can't be looked up")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if *hostKey == "" {
+		path, err := xdg.DataFile(filepath.Join("ssh", "host_ed25519"))
+		if err != nil {
+			return err
+		}
+		*hostKey = path
+	}
+	if err := os.MkdirAll(filepath.Dir(*hostKey), 0o700); err != nil {
+		return err
+	}
+	dict, err := words.Builtin(wordle.DefaultWordSize)
+	if err != nil {
+		return err
+	}
+	// every session plays the same daily puzzle
+	daily := words.NewDaily(dict, *salt, nil)
+
+	srv, err := wish.NewServer(
+		wish.WithAddress(*addr),
+		wish.WithHostKeyPath(*hostKey),
+		// anyone can play, but only players with a key get to keep stats
+		wish.WithPublicKeyAuth(func(ssh.Context, ssh.PublicKey) bool { return true }),
+		wish.WithKeyboardInteractiveAuth(func(ssh.Context, gossh.KeyboardInteractiveChallenge) bool { return true }),
+		wish.WithMiddleware(
+			bm.Middleware(sshHandler(daily)),
+			activeterm.Middleware(),
+			logging.Middleware(),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Serving godle over SSH on %s\n", *addr)
+	err = serveUntilStopped(srv.ListenAndServe, srv.Close)
+	if errors.Is(err, ssh.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// sshHandler returns the handler that gives each SSH session its own game,
+// starting on the daily puzzle from daily.
+func sshHandler(daily words.WordSource) bm.Handler {
+	return func(s ssh.Session) (tea.Model, []tea.ProgramOption) {
+		cfg, err := sessionConfig(s, daily)
+		if errors.Is(err, flag.ErrHelp) {
+			_ = s.Exit(0)
+			return nil, nil
+		}
+		if err != nil {
+			_ = s.Exit(2)
+			return nil, nil
+		}
+		return initialModel(cfg), nil
+	}
+}
+
+// sessionConfig returns the config for an SSH session's game, from the flags
+// the player passed as the session's command.
+func sessionConfig(s ssh.Session, daily words.WordSource) (config, error) {
+	pty, _, _ := s.Pty()
+	env := sessionEnv(append(s.Environ(), "TERM="+pty.Term))
+
+	cfg := config{
+		rules:     wordle.DefaultRules(),
+		boards:    1,
+		daily:     daily,
+		resume:    true,
+		animate:   true,
+		clipboard: s,
+		getenv:    env.Getenv,
+		keyboard:  keyboardLayouts[defaultKeyboard],
+	}
+	if layout, err := findKeyboard(env.Getenv("GODLE_KEYBOARD")); err == nil {
+		cfg.keyboard = layout
+	}
+
+	fs := flag.NewFlagSet("godle", flag.ContinueOnError)
+	fs.SetOutput(s.Stderr())
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage:\n  ssh -t HOST -- [flags]\n\nFlags:")
+		fs.PrintDefaults()
+	}
+	fs.BoolVar(&cfg.rules.HardMode, "hard", cfg.rules.HardMode, "require revealed hints to be used in later guesses")
+	fs.BoolVar(&cfg.asciiShare, "ascii", cfg.asciiShare, "share results with plain characters instead of emoji")
+	fs.BoolVar(&cfg.animate, "animate", cfg.animate, "animate tiles as they're revealed; -animate=false turns it off")
+	fs.Var(sessionThemeFlag{themeFlag{&cfg.theme}}, "theme", "colours to draw the game in: auto, "+strings.Join(themeList(), ", ")+" or one of the server's themes")
+	fs.Var(keyboardFlag{&cfg.keyboard}, "keyboard", "layout of the keyboard under the board: "+strings.Join(keyboardList(), ", ")+" (also set by GODLE_KEYBOARD)")
+	if err := fs.Parse(s.Command()); err != nil {
+		return cfg, err
+	}
+
+	cfg.renderer = lipgloss.NewRenderer(s, termenv.WithEnvironment(env), termenv.WithUnsafe())
+	if cfg.theme == nil {
+		cfg.theme = autoTheme(cfg.renderer)
+	}
+
+	if key := s.PublicKey(); key != nil {
+		dir, err := playerDir(key)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.statsPath = filepath.Join(dir, "stats.json")
+		cfg.savePath = filepath.Join(dir, "game.json")
+	}
+	return cfg, nil
+}
+
+// playerDir returns the directory that the stats and saved game of the
+// player with key are kept in.
+func playerDir(key ssh.PublicKey) (string, error) {
+	sum := sha256.Sum256(key.Marshal())
+	return xdg.DataFile(filepath.Join("players", hex.EncodeToString(sum[:])))
+}
+
+// sessionThemeFlag is a themeFlag that only takes names, so that players
+// can't have the server read files of their choosing.
+type sessionThemeFlag struct {
+	themeFlag
+}
+
+func (f sessionThemeFlag) Set(s string) error {
+	if strings.ContainsRune(s, filepath.Separator) || filepath.Ext(s) != "" {
+		return fmt.Errorf("unknown theme %q", s)
+	}
+	return f.themeFlag.Set(s)
+}
+
+// sessionEnv is the environment of an SSH session's terminal, as
+// KEY=value pairs.
+type sessionEnv []string
+
+func (e sessionEnv) Environ() []string {
+	return e
+}
+
+func (e sessionEnv) Getenv(key string) string {
+	// later values win, like they do for a process
+	for i := len(e) - 1; i >= 0; i-- {
+		if k, v, ok := strings.Cut(e[i], "="); ok && k == key {
+			return v
+		}
+	}
+	return ""
+}
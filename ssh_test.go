@@ -0,0 +1,104 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/bianxm/godle/words"
+
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish/testsession"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// sessionResult is what sessionConfig returned for a test session.
+type sessionResult struct {
+	cfg config
+	err error
+}
+
+// startSession runs command in a session on srv, logged in with signer if
+// it's not nil, and returns what sessionConfig made of it.
+func startSession(t *testing.T, srv *ssh.Server, results chan sessionResult, signer gossh.Signer, command string) sessionResult {
+	t.Helper()
+	auth := []gossh.AuthMethod{gossh.Password("")}
+	if signer != nil {
+		auth = []gossh.AuthMethod{gossh.PublicKeys(signer)}
+	}
+	addr := testsession.Listen(t, srv)
+	sess, err := testsession.NewClientSession(t, addr, &gossh.ClientConfig{User: "player", Auth: auth})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = sess.Run(command)
+	return <-results
+}
+
+func newSigner(t *testing.T) gossh.Signer {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := gossh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer
+}
+
+func TestSessionConfig(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	dict, err := words.Builtin(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	daily := words.NewDaily(dict, "", nil)
+	results := make(chan sessionResult, 1)
+	newServer := func() *ssh.Server {
+		return &ssh.Server{
+			Handler: func(s ssh.Session) {
+				cfg, err := sessionConfig(s, daily)
+				results <- sessionResult{cfg, err}
+			},
+			PublicKeyHandler: func(ssh.Context, ssh.PublicKey) bool { return true },
+			PasswordHandler:  func(ssh.Context, string) bool { return true },
+		}
+	}
+
+	alice, bob := newSigner(t), newSigner(t)
+	first := startSession(t, newServer(), results, alice, "-hard -keyboard dvorak")
+	if first.err != nil {
+		t.Fatalf("sessionConfig returned error: %v", first.err)
+	}
+	if !first.cfg.rules.HardMode || first.cfg.keyboard.name != "dvorak" {
+		t.Errorf("Flags weren't applied: hard = %v, keyboard = %s", first.cfg.rules.HardMode, first.cfg.keyboard.name)
+	}
+	if first.cfg.daily != daily {
+		t.Errorf("Session should play the shared daily puzzle, but doesn't")
+	}
+	if first.cfg.statsPath == "" || first.cfg.savePath == "" {
+		t.Errorf("Player with a key should keep stats and saves, but statsPath = %q and savePath = %q", first.cfg.statsPath, first.cfg.savePath)
+	}
+
+	again := startSession(t, newServer(), results, alice, "")
+	if again.cfg.statsPath != first.cfg.statsPath {
+		t.Errorf("Same key got stats %q, then %q", first.cfg.statsPath, again.cfg.statsPath)
+	}
+	if again.cfg.rules.HardMode {
+		t.Errorf("Flags from another session shouldn't carry over")
+	}
+	other := startSession(t, newServer(), results, bob, "")
+	if other.cfg.statsPath == first.cfg.statsPath {
+		t.Errorf("Different keys share stats %q", other.cfg.statsPath)
+	}
+	anonymous := startSession(t, newServer(), results, nil, "")
+	if anonymous.cfg.statsPath != "" || anonymous.cfg.savePath != "" {
+		t.Errorf("Player without a key shouldn't keep stats or saves, but statsPath = %q and savePath = %q", anonymous.cfg.statsPath, anonymous.cfg.savePath)
+	}
+
+	if res := startSession(t, newServer(), results, alice, "-theme /etc/passwd"); res.err == nil {
+		t.Errorf("Theme files shouldn't be allowed over SSH")
+	}
+}
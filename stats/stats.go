@@ -0,0 +1,88 @@
+// Package stats keeps a player's record across games.
+package stats
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/bianxm/godle/xdg"
+)
+
+// Stats is a player's record across games.
+type Stats struct {
+	Played        int
+	Wins          int
+	CurrentStreak int
+	MaxStreak     int
+	// Distribution counts won games by the number of guesses they took.
+	Distribution map[int]int
+}
+
+// DefaultPath returns where stats are stored unless told otherwise.
+func DefaultPath() (string, error) {
+	return xdg.DataFile("stats.json")
+}
+
+// Record adds a finished game to the stats.
+func (s *Stats) Record(won bool, guesses int) {
+	s.Played++
+	if !won {
+		s.CurrentStreak = 0
+		return
+	}
+	s.Wins++
+	s.CurrentStreak++
+	if s.CurrentStreak > s.MaxStreak {
+		s.MaxStreak = s.CurrentStreak
+	}
+	if s.Distribution == nil {
+		s.Distribution = make(map[int]int)
+	}
+	s.Distribution[guesses]++
+}
+
+// WinPercentage returns the percentage of played games that were won, rounded
+// to the nearest whole number.
+func (s Stats) WinPercentage() int {
+	if s.Played == 0 {
+		return 0
+	}
+	return (s.Wins*200 + s.Played) / (s.Played * 2)
+}
+
+// Load reads stats from path. A missing file isn't an error: it just means no
+// games have been played yet.
+func Load(path string) (Stats, error) {
+	var s Stats
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(b, &s); err != nil {
+		return Stats{}, errors.New("Corrupt stats file " + path + ": " + err.Error())
+	}
+	return s, nil
+}
+
+// Save writes the stats to path, creating its directory if needed.
+func (s Stats) Save(path string) error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	// write to a temporary file first so a crash can't leave half a file
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,53 @@
+package stats
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestRecord(t *testing.T) {
+	var s Stats
+	s.Record(true, 3)
+	s.Record(true, 4)
+	s.Record(false, 6)
+	s.Record(true, 3)
+
+	want := Stats{
+		Played:        4,
+		Wins:          3,
+		CurrentStreak: 1,
+		MaxStreak:     2,
+		Distribution:  map[int]int{3: 2, 4: 1},
+	}
+	if !reflect.DeepEqual(s, want) {
+		t.Errorf("stats = %+v, want %+v", s, want)
+	}
+	if p := s.WinPercentage(); p != 75 {
+		t.Errorf("WinPercentage() = %d, want 75", p)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "godle", "stats.json")
+
+	s, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() of missing file returned error: %s", err)
+	}
+	if s.Played != 0 {
+		t.Errorf("Played = %d, want 0", s.Played)
+	}
+
+	s.Record(true, 2)
+	if err := s.Save(path); err != nil {
+		t.Fatalf("Save() returned error: %s", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() returned error: %s", err)
+	}
+	if !reflect.DeepEqual(s, loaded) {
+		t.Errorf("loaded %+v, want %+v", loaded, s)
+	}
+}
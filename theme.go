@@ -0,0 +1,306 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bianxm/godle/wordle"
+	"github.com/bianxm/godle/xdg"
+
+	"github.com/BurntSushi/toml"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme is the colours the game is drawn in. An empty colour is the
+// terminal's own.
+type Theme struct {
+	Name string `json:"name" toml:"name"`
+	// Text is for letters that haven't been guessed and most writing, and
+	// Muted is for absent letters, empty rows and anything less important.
+	Text  lipgloss.Color `json:"text" toml:"text"`
+	Muted lipgloss.Color `json:"muted" toml:"muted"`
+	// Correct and Present are for letters in the right place and letters
+	// elsewhere in the word.
+	Correct lipgloss.Color `json:"correct" toml:"correct"`
+	Present lipgloss.Color `json:"present" toml:"present"`
+	Error   lipgloss.Color `json:"error" toml:"error"`
+	// TileText is for letters on tiles filled with a colour.
+	TileText lipgloss.Color `json:"tile_text" toml:"tile_text"`
+	// Marks shows how letters did with borders, underlines and symbols as
+	// well as colour, for when the colours can't be told apart.
+	Marks bool `json:"marks" toml:"marks"`
+
+	// renderer draws the styles, for when there's more than one terminal to
+	// draw on. If it's nil, it's lipgloss's default renderer.
+	renderer *lipgloss.Renderer
+}
+
+var (
+	themeDark = Theme{
+		Name:     "dark",
+		Text:     "#d7dadc",
+		Muted:    "#626262",
+		Correct:  "#538d4e",
+		Present:  "#b59f3b",
+		Error:    "#c9514c",
+		TileText: "#ffffff",
+	}
+	themeLight = Theme{
+		Name:     "light",
+		Text:     "#1a1a1b",
+		Muted:    "#a0a3a5",
+		Correct:  "#6aaa64",
+		Present:  "#c9b458",
+		Error:    "#d1453b",
+		TileText: "#ffffff",
+	}
+	// themeHighContrast swaps green and yellow for orange and blue, which are
+	// easier to tell apart for most kinds of colour blindness.
+	themeHighContrast = Theme{
+		Name:     "high-contrast",
+		Text:     "#ffffff",
+		Muted:    "#818384",
+		Correct:  "#f5793a",
+		Present:  "#85c0f9",
+		Error:    "#ff5f5f",
+		TileText: "#000000",
+	}
+	themeMonochrome = Theme{
+		Name:  "monochrome",
+		Marks: true,
+	}
+)
+
+// builtinThemes are the themes that come with godle, by name.
+var builtinThemes = map[string]*Theme{
+	themeDark.Name:         &themeDark,
+	themeLight.Name:        &themeLight,
+	themeHighContrast.Name: &themeHighContrast,
+	themeMonochrome.Name:   &themeMonochrome,
+}
+
+// autoTheme returns the dark or light theme to suit the background of the
+// terminal r draws on, or the terminal godle's running in if r is nil.
+func autoTheme(r *lipgloss.Renderer) *Theme {
+	if r == nil {
+		r = lipgloss.DefaultRenderer()
+	}
+	if r.HasDarkBackground() {
+		return &themeDark
+	}
+	return &themeLight
+}
+
+// themesDir returns the directory players can keep their own themes in.
+func themesDir() (string, error) {
+	return xdg.ConfigFile("themes")
+}
+
+// findTheme returns the built-in theme called name, or else the player's
+// theme from name.toml or name.json in the themes directory. name can also
+// be the path of a theme file.
+func findTheme(name string) (*Theme, error) {
+	if t, ok := builtinThemes[strings.ToLower(name)]; ok {
+		return t, nil
+	}
+	if strings.ContainsRune(name, filepath.Separator) || filepath.Ext(name) != "" {
+		return loadTheme(name)
+	}
+	dir, err := themesDir()
+	if err != nil {
+		return nil, err
+	}
+	for _, ext := range []string{".toml", ".json"} {
+		path := filepath.Join(dir, name+ext)
+		if _, err := os.Stat(path); err == nil {
+			return loadTheme(path)
+		}
+	}
+	return nil, fmt.Errorf("unknown theme %q, want one of %s or a file in %s", name, strings.Join(themeList(), ", "), dir)
+}
+
+// themeFile is a theme as it's written in a file. Colours that are left out
+// come from the base theme, which is dark unless it says otherwise.
+type themeFile struct {
+	Base string `json:"base" toml:"base"`
+	Theme
+}
+
+// loadTheme reads a theme from a TOML or JSON file, going by its extension.
+func loadTheme(path string) (*Theme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var decode func(v any) error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		decode = func(v any) error { return toml.Unmarshal(data, v) }
+	case ".json":
+		decode = func(v any) error { return json.Unmarshal(data, v) }
+	default:
+		return nil, fmt.Errorf("theme %s should be a .toml or .json file", path)
+	}
+
+	// read it once to find the base, then again on top of the base
+	var tf themeFile
+	if err := decode(&tf); err != nil {
+		return nil, fmt.Errorf("can't read theme %s: %w", path, err)
+	}
+	base := &themeDark
+	if tf.Base != "" {
+		var ok bool
+		if base, ok = builtinThemes[strings.ToLower(tf.Base)]; !ok {
+			return nil, fmt.Errorf("theme %s: unknown base %q, want one of %s", path, tf.Base, strings.Join(themeList(), ", "))
+		}
+	}
+	tf.Theme = *base
+	tf.Theme.Name = ""
+	if err := decode(&tf); err != nil {
+		return nil, fmt.Errorf("can't read theme %s: %w", path, err)
+	}
+	t := tf.Theme
+	if t.Name == "" {
+		t.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return &t, nil
+}
+
+// themeList returns the names of the built-in themes in alphabetical order.
+func themeList() []string {
+	names := make([]string, 0, len(builtinThemes))
+	for name := range builtinThemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// themeFlag is a flag.Value that picks a theme by name, or picks one to suit
+// the terminal if it's "auto".
+type themeFlag struct {
+	theme **Theme
+}
+
+func (f themeFlag) String() string {
+	if f.theme == nil || *f.theme == nil {
+		return "auto"
+	}
+	return (*f.theme).Name
+}
+
+func (f themeFlag) Set(s string) error {
+	if strings.ToLower(s) == "auto" {
+		*f.theme = nil
+		return nil
+	}
+	t, err := findTheme(s)
+	if err != nil {
+		return err
+	}
+	*f.theme = t
+	return nil
+}
+
+// withRenderer returns a copy of t that draws with r.
+func (t *Theme) withRenderer(r *lipgloss.Renderer) *Theme {
+	c := *t
+	c.renderer = r
+	return &c
+}
+
+func (t *Theme) newStyle() lipgloss.Style {
+	if t.renderer == nil {
+		return lipgloss.NewStyle()
+	}
+	return t.renderer.NewStyle()
+}
+
+// statusColor returns the colour of a letter that did as ls says.
+func (t *Theme) statusColor(ls wordle.LetterStatus) lipgloss.Color {
+	switch ls {
+	case wordle.Absent:
+		return t.Muted
+	case wordle.Present:
+		return t.Present
+	case wordle.Correct:
+		return t.Correct
+	default:
+		return t.Text
+	}
+}
+
+// statusStyle returns the style of a letter that did as ls says.
+func (t *Theme) statusStyle(ls wordle.LetterStatus) lipgloss.Style {
+	style := t.newStyle().Foreground(t.statusColor(ls))
+	if !t.Marks {
+		return style
+	}
+	switch ls {
+	case wordle.Absent:
+		return style.Faint(true)
+	case wordle.Present:
+		return style.Underline(true)
+	case wordle.Correct:
+		return style.Underline(true).Bold(true)
+	default:
+		return style
+	}
+}
+
+// statusBorder returns the border of a tile for a letter that did as ls says.
+func (t *Theme) statusBorder(ls wordle.LetterStatus) lipgloss.Border {
+	if !t.Marks {
+		return lipgloss.NormalBorder()
+	}
+	switch ls {
+	case wordle.Present:
+		return lipgloss.RoundedBorder()
+	case wordle.Correct:
+		return lipgloss.DoubleBorder()
+	default:
+		return lipgloss.NormalBorder()
+	}
+}
+
+// statusSymbol returns the symbol for a letter that did as ls says, where
+// there's only room for one cell.
+func (t *Theme) statusSymbol(ls wordle.LetterStatus) string {
+	if !t.Marks {
+		return "█"
+	}
+	switch ls {
+	case wordle.Absent:
+		return "·"
+	case wordle.Present:
+		return "▒"
+	case wordle.Correct:
+		return "█"
+	default:
+		return "░"
+	}
+}
+
+// renderTile draws letter in a box showing how it did.
+func (t *Theme) renderTile(letter string, ls wordle.LetterStatus) string {
+	return t.statusStyle(ls).
+		Padding(0, 1).
+		Border(t.statusBorder(ls)).
+		BorderForeground(t.statusColor(ls)).
+		Render(letter)
+}
+
+func (t *Theme) severityColor(sev severity) lipgloss.Color {
+	switch sev {
+	case severityError:
+		return t.Error
+	case severitySuccess:
+		return t.Correct
+	default:
+		return t.Text
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bianxm/godle/wordle"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestLoadTheme(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	themes := filepath.Join(dir, "godle", "themes")
+	if err := os.MkdirAll(themes, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"sunset.toml": "base = \"light\"\ncorrect = \"#ff8800\"\nmarks = true\n",
+		"night.json":  `{"name": "Night", "present": "#0000ff"}`,
+		"broken.toml": "correct = ",
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(themes, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sunset, err := findTheme("sunset")
+	if err != nil {
+		t.Fatalf("findTheme(sunset) returned error: %v", err)
+	}
+	want := themeLight
+	want.Name, want.Correct, want.Marks = "sunset", "#ff8800", true
+	if *sunset != want {
+		t.Errorf("sunset = %+v, want %+v", *sunset, want)
+	}
+
+	night, err := findTheme("night")
+	if err != nil {
+		t.Fatalf("findTheme(night) returned error: %v", err)
+	}
+	want = themeDark
+	want.Name, want.Present = "Night", "#0000ff"
+	if *night != want {
+		t.Errorf("night = %+v, want %+v", *night, want)
+	}
+
+	for _, name := range []string{"broken", "missing", filepath.Join(themes, "night.txt")} {
+		if _, err := findTheme(name); err == nil {
+			t.Errorf("findTheme(%q) should have returned an error, but didn't", name)
+		}
+	}
+
+	if theme, err := findTheme("High-Contrast"); err != nil || theme != &themeHighContrast {
+		t.Errorf("findTheme(High-Contrast) = %v, %v, want the built-in theme", theme, err)
+	}
+}
+
+func TestThemeStatuses(t *testing.T) {
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	defer lipgloss.SetColorProfile(termenv.Ascii)
+	for _, theme := range builtinThemes {
+		// unknown statuses look like letters that haven't been guessed
+		if got := theme.statusColor(wordle.LetterStatus(42)); got != theme.Text {
+			t.Errorf("%s: statusColor(42) = %q, want %q", theme.Name, got, theme.Text)
+		}
+		if theme.renderTile("A", wordle.Correct) == theme.renderTile("A", wordle.Present) {
+			t.Errorf("%s: correct and present tiles look the same", theme.Name)
+		}
+	}
+}
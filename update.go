@@ -2,49 +2,114 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"strings"
 
+	"github.com/aymanbagabas/go-osc52/v2"
+
+	"github.com/bianxm/godle/solver"
 	"github.com/bianxm/godle/wordle"
 	"github.com/bianxm/godle/words"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m.handleDebugMsg(msg)
+	cmd := m.handleMsg(msg)
+	// whatever happened may have put up a notice that needs timing, or
+	// started an animation
+	return m, tea.Batch(cmd, m.handleNoticeTimer(), m.handleAnimationTick())
+}
+
+func (m *model) handleMsg(msg tea.Msg) tea.Cmd {
 	switch msg := msg.(type) {
 	case msgResetStatus:
-		// If there is more than one pending status message, that means
-		// something else is currently displaying a status message, so we don't
-		// want to overwrite it.
-		m.statusPending--
-		if m.statusPending == 0 {
+		m.handleResetNotice(msg)
+
+	case msgHint:
+		// the player may have moved on while the solver was thinking
+		if msg.ws == m.ws && msg.guess == m.ws.CurrGuess && !m.gameOver {
+			m.hint = &msg
 			m.handleResetStatus()
 		}
 
+	case msgAnimFrame:
+		m.handleAnimFrame(msg)
+
+	case msgRace:
+		return m.handleRaceMsg(msg)
+
+	case msgRaceClosed:
+		m.handleRaceClosed(msg)
+
+	case msgComputerGuess:
+		if msg.ws == m.ws && !m.gameOver {
+			return m.handleComputerGuess(msg)
+		}
+
 	// Handle keypresses
 	case tea.KeyMsg:
+		if m.anim != nil && msg.Type != tea.KeyCtrlD && msg.Type != tea.KeyCtrlG {
+			// keys would change what's being animated
+			return nil
+		}
+		if m.marking && msg.Type != tea.KeyCtrlD && msg.Type != tea.KeyCtrlG {
+			return m.handleMarkingKey(msg)
+		}
 		switch msg.Type {
 		case tea.KeyCtrlD:
-			return m, tea.Quit
+			return tea.Quit
+
+		case tea.KeyCtrlG:
+			m.handleToggleDebug()
 
 		case tea.KeyBackspace:
 			m.handleDeleteChar()
 
+		case tea.KeyTab:
+			m.handleAcceptSuggestion()
+
 		case tea.KeyEnter:
-			if m.gameOver {
+			if m.mode == modeRace {
+				m.handleRaceEnter()
+				return nil
+			} else if m.gameOver && m.mode == modeReverse {
+				m.handleStartReverse()
+				return nil
+			} else if m.choosing {
+				return m.handleChooseWord()
+			} else if m.mode == modeReverse {
+				// the computer is thinking
+				return nil
+			} else if m.gameOver {
 				// new game initialization
 				m.handleResetStatus()
 				m.handleResetActiveGuess()
 				m.handleResetWordleState()
 				m.gameOver = false
-				return m, nil
+				return nil
+			} else if m.mode == modeAssistant {
+				m.handleStartMarking()
+			} else if m.mode == modeMulti {
+				m.handleSubmitMultiGuess()
+				m.handleShouldEndGame()
 			} else {
 				m.handleSubmitActiveGuess()
 				m.handleShouldEndGame()
 			}
 
 		case tea.KeyRunes:
-			if len(msg.Runes) == 1 && !m.gameOver {
+			if string(msg.Runes) == "?" && !m.gameOver && m.mode != modeReverse && m.mode != modeMulti && m.mode != modeRace {
+				return m.handleHint()
+			} else if m.mode == modeReverse && !m.choosing {
+				// the computer is doing the typing
+				return nil
+			} else if len(msg.Runes) == 1 && !m.gameOver {
 				m.handleSubmitChar(msg.Runes[0])
+			} else if string(msg.Runes) == "s" && m.gameOver && m.mode != modeMulti && m.mode != modeRace {
+				return m.handleShare()
 			}
 		}
 
@@ -52,62 +117,241 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 	}
-	return m, nil
+	return nil
 }
 
 func (m *model) handleResetWordleState() {
-	ws := wordle.NewWordleState(words.GetWord())
-	m.ws = &ws
+	m.hint = nil
+	m.challengeResult = ""
+	if m.mode == modeAbsurd {
+		m.handleResetAbsurdState()
+		return
+	}
+	if m.mode == modeMulti {
+		m.handleResetMultiState()
+		return
+	}
+	if m.mode == modeAssistant || m.mode == modeReverse {
+		ws := wordle.NewAssistantState(m.rules)
+		m.handleNewState(&ws)
+		return
+	}
+	if m.challenge != "" {
+		ws := wordle.NewWordleState(m.challengeWord, m.rules)
+		m.handleNewState(&ws)
+		return
+	}
+	source := m.source
+	if m.daily {
+		source = m.dailySource
+	}
+	pick := source.Next()
+	ws := wordle.NewWordleState(pick.Word, m.rules)
+	ws.Daily = pick.Daily
+	m.handleNewState(&ws)
 }
 
 func (m *model) handleShouldEndGame() {
 	ws := m.ws
 	m.gameOver = ws.ShouldEndGame()
-	if m.gameOver {
+	if m.mode == modeMulti {
+		m.gameOver = m.multi.ShouldEndGame()
+	}
+	if m.gameOver && m.mode == modeMulti {
+		m.handleMultiGameOver()
+	} else if m.gameOver && m.mode == modeAbsurd {
+		m.handleAbsurdGameOver()
+	} else if m.gameOver && m.mode == modeReverse {
+		m.cursor = -1
+		if ws.IsWordGuessed() {
+			m.handleSetStatus(fmt.Sprintf("Got it in %d!\nPress ENTER to play again", ws.CurrGuess))
+		} else {
+			m.handleSetStatus("I couldn't get it :(\nPress ENTER to play again")
+		}
+	} else if m.gameOver && m.mode == modeAssistant {
 		m.cursor = -1
 		if ws.IsWordGuessed() {
-			// m.handleSetStatus("Word guessed!\nPress ENTER to restart", 1*time.Second)
-			m.handleSetStatus("Word guessed!\nPress ENTER to restart")
+			m.handleSetStatus("Solved!\nPress ENTER to help with another game")
+		} else {
+			m.handleSetStatus("Out of guesses :(\nPress ENTER to help with another game")
+		}
+	} else if m.gameOver {
+		m.cursor = -1
+		restart := "Press ENTER to restart, S to share"
+		if m.daily {
+			// there's only one daily puzzle a day, so carry on in free play
+			m.daily = false
+			restart = "Press ENTER for a free play game, S to share"
+		}
+		if m.challenge != "" {
+			restart = m.handleChallengeResult() + "\nPress ENTER for a free play game, S to share"
+		}
+		if ws.IsWordGuessed() {
+			m.handleSetStatus("Word guessed!\n" + restart)
 		} else {
 			// means that there's no more guesses
-			// m.handleSetStatus(fmt.Sprintf("No more guesses :( Word was %s\nPress ENTER to restart", string(ws.Word[:])), 1*time.Second)
-			m.handleSetStatus(fmt.Sprintf("No more guesses :( Word was %s\nPress ENTER to restart", string(ws.Word[:])))
+			m.handleSetStatus(fmt.Sprintf("No more guesses :( Word was %s\n%s", string(ws.Word[:]), restart))
 		}
+		m.handleRecordStats()
 	}
 
 }
 
+// handleRecordStats adds the finished game to the player's stats and saves
+// them.
+func (m *model) handleRecordStats() {
+	m.stats.Record(m.ws.IsWordGuessed(), m.ws.CurrGuess)
+	if m.statsPath == "" {
+		return
+	}
+	if err := m.stats.Save(m.statsPath); err != nil {
+		m.handleError(fmt.Errorf("Couldn't save stats: %w", err))
+	}
+}
+
 func (m *model) handleSubmitActiveGuess() {
 	ws := m.ws
 	// only submit until the cursor :)
 	g := wordle.NewGuess(string(m.activeGuess[:m.cursor]))
-	g.UpdateLettersWithWord(ws.Word)
-
-	err := ws.AppendGuess(g)
-	if err != nil {
-		// m.handleSetStatus(err.Error(), 1*time.Second)
-		m.handleSetStatus(err.Error())
+	// the adversary narrows down its words when it scores, so the guess is
+	// only scored once it's known to be valid
+	if err := ws.ValidateGuess(g); err != nil {
+		// hard mode errors explain which hint the guess left out
+		m.handleError(err)
+		m.handleStartAnimation(animShake, ws.CurrGuess)
 		return
 	}
+	ws.Score(g)
+	if err := ws.AppendGuess(g); err != nil {
+		m.handleError(err)
+		return
+	}
+	m.handleStartAnimation(animFlip, ws.CurrGuess-1)
 	// fmt.Println(m.ws.Alphabet)
 	m.handleResetStatus()
 	// reset status to "Guess the word"
 	m.handleResetActiveGuess()
+	m.hint = nil
+	m.handleSaveGame()
+}
+
+// handleSaveGame autosaves the current game. The daily puzzle has a save of
+// its own, so free play afterwards doesn't replace it.
+func (m *model) handleSaveGame() {
+	if m.savePath == "" {
+		return
+	}
+	path := m.savePath
+	if m.ws.Daily != nil {
+		path = dailySavePath(path)
+	}
+	if err := saveGame(path, savedGame{Mode: modeName(m.mode), Game: m.ws}); err != nil {
+		m.handleError(fmt.Errorf("Couldn't save game: %w", err))
+	}
+}
+
+// handleResumeGame restores the saved game if it's the kind of game the player
+// asked for, in the same mode. Daily puzzles are only resumed on the day
+// they're for, and a finished daily puzzle is shown as finished so it can't be
+// replayed.
+func (m *model) handleResumeGame() {
+	path := m.savePath
+	if m.daily {
+		path = dailySavePath(path)
+	}
+	game, err := loadGame(path)
+	if err != nil {
+		m.handleError(err)
+		return
+	}
+	if game == nil || game.Mode != modeName(m.mode) {
+		return
+	}
+	saved := game.Game
+	if saved.Rules != m.rules {
+		return
+	}
+
+	if m.daily {
+		// handleResetWordleState has already set up today's puzzle
+		today := m.ws.Daily
+		if saved.Daily == nil || saved.Daily.Salt != today.Salt {
+			return
+		}
+		if saved.Daily.Date != today.Date {
+			if !saved.ShouldEndGame() {
+				m.handleNotify(fmt.Sprintf("Daily puzzle #%d has expired", saved.Daily.Number), severityInfo, errorDuration)
+			}
+			return
+		}
+	} else if saved.Daily != nil || saved.ShouldEndGame() {
+		return
+	}
+
+	m.handleNewState(saved)
+	if saved.ShouldEndGame() {
+		m.gameOver = true
+		m.cursor = -1
+		m.daily = false
+		m.handleSetStatus("You've already played today's puzzle\nPress ENTER for a free play game, S to share")
+	}
 }
 
 func (m *model) handleResetActiveGuess() {
-	copy(m.activeGuess[:], "")
+	for i := range m.activeGuess {
+		m.activeGuess[i] = 0
+	}
 	m.cursor = 0
+	m.suggestions = nil
+}
+
+// maxSuggestions is how many words are suggested for a guess that isn't one.
+const maxSuggestions = 3
+
+// prepareSuggestions returns a tea.Cmd that gets dict ready to suggest words
+// in the background, so that the first guess that isn't a word doesn't hold
+// up the game.
+func prepareSuggestions(dict *words.Dictionary) tea.Cmd {
+	return func() tea.Msg {
+		dict.PrepareSuggest()
+		return nil
+	}
+}
+
+// handleSuggest suggests words the typed guess might have been meant to be,
+// if err says it isn't a word.
+func (m *model) handleSuggest(err error) {
+	m.suggestions = nil
+	var invalid *wordle.InvalidGuessError
+	if errors.As(err, &invalid) && invalid.Reason == wordle.ReasonNotInDictionary {
+		m.suggestions = m.dict.Suggest(invalid.Word, maxSuggestions)
+	}
+}
+
+// handleAcceptSuggestion replaces the typed guess with the first suggestion.
+func (m *model) handleAcceptSuggestion() {
+	if len(m.suggestions) == 0 || m.gameOver || len(m.suggestions[0]) != len(m.activeGuess) {
+		return
+	}
+	copy(m.activeGuess, m.suggestions[0])
+	m.cursor = len(m.activeGuess)
+	m.suggestions = nil
+	m.rowError = false
 }
 
 func (m *model) handleDeleteChar() {
+	// the player is fixing the row, so stop pointing at it
+	m.rowError = false
+	m.suggestions = nil
 	if m.cursor > 0 {
 		m.cursor--
 	}
 }
 
 func (m *model) handleSubmitChar(r rune) {
-	if m.cursor < wordle.WordSize {
+	m.rowError = false
+	m.suggestions = nil
+	if m.cursor < len(m.activeGuess) {
 		if 'a' <= r && r <= 'z' {
 			r -= 'a' - 'A'
 		}
@@ -118,25 +362,88 @@ func (m *model) handleSubmitChar(r rune) {
 	}
 }
 
-// handleSetStatus sets the status message, and returns a tea.Cmd that restores the
-// default status message after a delay.
-// func (m *model) handleSetStatus(msg string, duration time.Duration) tea.Cmd {
+// handleShare returns a tea.Cmd that copies the share summary of the finished
+// game to the clipboard.
+func (m *model) handleShare() tea.Cmd {
+	m.handleNotify("Copied results to clipboard!", severitySuccess, noticeDuration)
+	share := m.ws.Share(m.asciiShare)
+	if m.challengeResult != "" {
+		share += "\nResult code " + m.challengeResult
+	}
+	return copyToClipboard(share, m.clipboard, m.getenv)
+}
+
+// copyToClipboard returns a tea.Cmd that copies s to the system clipboard of
+// the terminal that out goes to with an OSC52 escape sequence, which works
+// over SSH and inside tmux and screen. getenv looks up that terminal's
+// environment.
+func copyToClipboard(s string, out io.Writer, getenv func(string) string) tea.Cmd {
+	return func() tea.Msg {
+		seq := osc52.New(s)
+		if getenv("TMUX") != "" {
+			seq = seq.Tmux()
+		} else if strings.HasPrefix(getenv("TERM"), "screen") {
+			seq = seq.Screen()
+		}
+		seq.WriteTo(out)
+		return nil
+	}
+}
+
+// hintSuggestions is how many suggestions a hint shows.
+const hintSuggestions = 5
+
+// handleHint returns a tea.Cmd that runs the solver on the current game in the
+// background, since ranking every word can take a few seconds.
+func (m *model) handleHint() tea.Cmd {
+	if m.hint != nil {
+		return nil
+	}
+	m.handleSetStatus("Thinking...")
+
+	// the solver gets its own copy, since the game carries on while it runs
+	ws := *m.ws
+	ws.Guesses = append([]wordle.Guess(nil), ws.Guesses...)
+	game, guess := m.ws, m.ws.CurrGuess
+	return func() tea.Msg {
+		suggestions, remaining := solver.Suggest(&ws, hintSuggestions, solver.MaxEntropy)
+		return msgHint{
+			ws:          game,
+			guess:       guess,
+			suggestions: suggestions,
+			remaining:   remaining,
+		}
+	}
+}
+
+// handleSetStatus sets the status message, which stays until something
+// replaces it. Any notices waiting to show are dropped, since it's more
+// important.
 func (m *model) handleSetStatus(msg string) {
+	m.handleClearNotices()
 	m.status = msg
-	// if duration > 0 {
-	// 	m.statusPending++
-	// 	// fmt.Println("hi")
-	// 	return tea.Tick(duration, func(time.Time) tea.Msg {
-	// 		return msgResetStatus{}
-	// 	})
-	// }
-	// return nil
 }
 
-// handleResetStatus immediately resets the status message to its default value.
+// handleResetStatus immediately resets the status message to its default value,
+// dropping any notices.
 func (m *model) handleResetStatus() {
+	m.handleClearNotices()
+	if m.mode == modeReverse && m.choosing {
+		m.status = choosingStatus
+		return
+	}
+	if m.mode == modeAssistant {
+		m.status = "Type the guess you made"
+		return
+	}
 	m.status = "Guess the word!"
 }
 
-// msgResetStatus is sent when the status line should be reset.
-type msgResetStatus struct{}
+// msgHint is sent when the solver has finished ranking guesses for the game
+// ws, before its guessth guess.
+type msgHint struct {
+	ws          *wordle.WordleState
+	guess       int
+	suggestions []solver.Suggestion
+	remaining   int
+}
@@ -0,0 +1,347 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bianxm/godle/words"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newTestModel returns a model for free play games of word, without stats,
+// saves, debug or animations, unless the options change the config.
+func newTestModel(word string, options ...func(*config)) model {
+	cfg := defaultConfig()
+	cfg.source = words.Fixed(word)
+	cfg.statsPath = ""
+	cfg.savePath = ""
+	cfg.debug = false
+	cfg.animate = false
+	for _, option := range options {
+		option(&cfg)
+	}
+	return initialModel(cfg)
+}
+
+func update(m model, msg tea.Msg) (model, tea.Cmd) {
+	next, cmd := m.Update(msg)
+	return next.(model), cmd
+}
+
+// submit types word into m and presses enter.
+func submit(m model, word string) (model, tea.Cmd) {
+	for _, r := range word {
+		m, _ = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return update(m, tea.KeyMsg{Type: tea.KeyEnter})
+}
+
+func TestInvalidWordNotice(t *testing.T) {
+	m, cmd := submit(newTestModel("CRANE"), "ABCDE")
+	if len(m.notices) != 1 || m.notices[0].text != "Invalid word" || m.notices[0].severity != severityError {
+		t.Fatalf("Notices = %+v, want an Invalid word error", m.notices)
+	}
+	if cmd == nil {
+		t.Errorf("Should return a timer for the notice, but didn't")
+	}
+	if !m.rowError {
+		t.Errorf("Row should be highlighted, but isn't")
+	}
+	if !strings.Contains(m.renderStatus(), "Invalid word") {
+		t.Errorf("Status shows %q, want Invalid word", m.renderStatus())
+	}
+
+	m, _ = update(m, msgResetStatus{id: m.notices[0].id})
+	if len(m.notices) != 0 || m.rowError {
+		t.Errorf("Notice should be gone once its time is up, but notices = %+v and rowError = %v", m.notices, m.rowError)
+	}
+	if !strings.Contains(m.renderStatus(), "Guess the word!") {
+		t.Errorf("Status shows %q, want the default", m.renderStatus())
+	}
+}
+
+func TestNoticeQueue(t *testing.T) {
+	m, _ := submit(newTestModel("CRANE"), "ABCDE")
+	for i := 0; i < 5; i++ {
+		m, _ = update(m, tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	m, cmd := submit(m, "ABC")
+	if len(m.notices) != 2 {
+		t.Fatalf("Notices = %+v, want two", m.notices)
+	}
+	if cmd != nil {
+		t.Errorf("Second notice shouldn't be timed until the first is done")
+	}
+	first, second := m.notices[0], m.notices[1]
+
+	m, cmd = update(m, msgResetStatus{id: first.id})
+	if len(m.notices) != 1 || m.notices[0] != second {
+		t.Fatalf("Notices = %+v, want just %+v", m.notices, second)
+	}
+	if cmd == nil {
+		t.Errorf("Should return a timer for the next notice, but didn't")
+	}
+
+	// a timer that has already gone off shouldn't take down the next notice
+	m, _ = update(m, msgResetStatus{id: first.id})
+	if len(m.notices) != 1 {
+		t.Errorf("Notices = %+v, want the second one still showing", m.notices)
+	}
+}
+
+func TestNoticeAfterGameOver(t *testing.T) {
+	m, _ := submit(newTestModel("CRANE"), "ABCDE")
+	id := m.notices[0].id
+	for i := 0; i < 5; i++ {
+		m, _ = update(m, tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	if m.rowError {
+		t.Errorf("Fixing the row should stop highlighting it")
+	}
+	m, _ = submit(m, "CRANE")
+	if !m.gameOver {
+		t.Fatalf("Game should be over")
+	}
+
+	m, _ = update(m, msgResetStatus{id: id})
+	if !strings.HasPrefix(m.renderStatus(), "Word guessed!") {
+		t.Errorf("Status shows %q, want the game over message", m.renderStatus())
+	}
+}
+
+func TestShareNotice(t *testing.T) {
+	m, _ := submit(newTestModel("CRANE"), "CRANE")
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if len(m.notices) != 1 || m.notices[0].severity != severitySuccess {
+		t.Fatalf("Notices = %+v, want a success notice", m.notices)
+	}
+
+	// the game over message comes back afterwards
+	m, _ = update(m, msgResetStatus{id: m.notices[0].id})
+	if !strings.HasPrefix(m.renderStatus(), "Word guessed!") {
+		t.Errorf("Status shows %q, want the game over message", m.renderStatus())
+	}
+}
+
+func TestSuggestions(t *testing.T) {
+	m, _ := submit(newTestModel("CRANE"), "CRANX")
+	if len(m.suggestions) == 0 || len(m.suggestions) > maxSuggestions {
+		t.Fatalf("Suggestions = %v, want up to %d words", m.suggestions, maxSuggestions)
+	}
+	if !strings.Contains(m.renderHint(), "Did you mean") {
+		t.Errorf("Hint shows %q, want the suggestions", m.renderHint())
+	}
+	first := m.suggestions[0]
+
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyTab})
+	if got := string(m.activeGuess[:m.cursor]); got != first {
+		t.Errorf("Row is %q after TAB, want %s", got, first)
+	}
+	if m.suggestions != nil || m.rowError {
+		t.Errorf("Suggestions should be gone once one is taken, but are %v", m.suggestions)
+	}
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.ws.CurrGuess != 1 {
+		t.Errorf("Suggested word %s wasn't played", first)
+	}
+
+	// only words that aren't words get suggestions
+	m, _ = submit(m, "CR")
+	if m.suggestions != nil {
+		t.Errorf("Suggestions = %v for a short guess, want none", m.suggestions)
+	}
+}
+
+func TestAbsurdInvalidGuess(t *testing.T) {
+	m := newTestModel("CRANE", func(cfg *config) {
+		cfg.mode = modeAbsurd
+		cfg.rules.MaxGuesses = 0
+	})
+	before := m.adversary.Remaining()
+
+	for _, word := range []string{"ABCDE", "CRA"} {
+		m, _ = submit(m, word)
+		for i := 0; i < len(word); i++ {
+			m, _ = update(m, tea.KeyMsg{Type: tea.KeyBackspace})
+		}
+	}
+	if m.ws.CurrGuess != 0 || m.adversary.Remaining() != before {
+		t.Errorf("Invalid guesses left %d of %d candidates and CurrGuess = %d, want them untouched", m.adversary.Remaining(), before, m.ws.CurrGuess)
+	}
+}
+
+func TestDailySaveKept(t *testing.T) {
+	dir := t.TempDir()
+	newDaily := func() model {
+		return newTestModel("CRANE", func(cfg *config) {
+			cfg.dict, _ = words.Builtin(cfg.rules.WordSize)
+			cfg.daily = words.NewDaily(cfg.dict, "test", nil)
+			cfg.savePath = filepath.Join(dir, "game.json")
+		})
+	}
+
+	m := newDaily()
+	m, _ = submit(m, string(m.ws.Word))
+	if !m.gameOver {
+		t.Fatal("Daily puzzle should be over, but isn't")
+	}
+	// free play afterwards is saved too
+	m, _ = update(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = submit(m, "SLATE")
+	if m.ws.Daily != nil || m.ws.CurrGuess != 1 {
+		t.Fatalf("Should be in free play with a guess, but Daily = %v and CurrGuess = %d", m.ws.Daily, m.ws.CurrGuess)
+	}
+
+	m = newDaily()
+	if !m.gameOver || !strings.Contains(m.status, "already played") {
+		t.Errorf("Finished daily puzzle should stay finished, but status is %q", m.status)
+	}
+}
+
+func TestResumeOnlySameKindOfGame(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "game.json")
+	newGame := func() model {
+		return newTestModel("CRANE", func(cfg *config) { cfg.savePath = path })
+	}
+
+	m, _ := submit(newGame(), "SLATE")
+	saved, err := loadGame(path)
+	if err != nil || saved == nil || saved.Mode != "classic" {
+		t.Fatalf("Saved game = %+v, %v, want a classic game", saved, err)
+	}
+	if m := newGame(); m.ws.CurrGuess != 1 {
+		t.Errorf("Classic game wasn't resumed")
+	}
+	if err := saveGame(path, savedGame{Mode: "reverse", Game: m.ws}); err != nil {
+		t.Fatal(err)
+	}
+	if m := newGame(); m.ws.CurrGuess != 0 {
+		t.Errorf("Reverse game was resumed as classic")
+	}
+
+	// saves from before the mode was kept are classic free play
+	b, err := json.Marshal(m.ws)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if m := newGame(); m.ws.CurrGuess != 1 {
+		t.Errorf("Old save wasn't resumed")
+	}
+}
+
+func TestSeededGameNotSaved(t *testing.T) {
+	setTestEnv(t)
+	newGame := func(args ...string) model {
+		cfg, err := playConfig(args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cfg.statsPath = ""
+		cfg.animate = false
+		return initialModel(cfg)
+	}
+
+	m, _ := submit(newGame(), "SLATE")
+	if m.ws.CurrGuess != 1 {
+		t.Fatalf("CurrGuess = %d, want 1", m.ws.CurrGuess)
+	}
+	path := m.savePath
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m = newGame("-seed", "42")
+	if m.ws.CurrGuess != 0 {
+		t.Errorf("Seeded game resumed the free play game")
+	}
+	submit(m, "CRANE")
+	after, err := os.ReadFile(path)
+	if err != nil || string(after) != string(before) {
+		t.Errorf("Seeded game changed the free play save to\n%s", after)
+	}
+	if m := newGame(); m.ws.CurrGuess != 1 {
+		t.Errorf("Free play game wasn't resumed after a seeded game")
+	}
+}
+
+func TestMultiBoardsFromOneWord(t *testing.T) {
+	multi := func(cfg *config) {
+		cfg.mode = modeMulti
+		cfg.boards = 3
+		cfg.rules.MaxGuesses = 8
+	}
+	// Fixed only ever has the one word, so it has to go on every board
+	m := newTestModel("CRANE", multi)
+	if len(m.multi.Boards) != 3 {
+		t.Fatalf("%d boards, want 3", len(m.multi.Boards))
+	}
+	for _, ws := range m.multi.Boards {
+		if string(ws.Word) != "CRANE" {
+			t.Errorf("Board has word %s, want CRANE", ws.Word)
+		}
+	}
+
+	m = newTestModel("CRAN", multi)
+	if len(m.notices) == 0 || !strings.Contains(m.notices[0].text, "Couldn't start the boards") {
+		t.Errorf("Notices = %+v, want an error about the boards", m.notices)
+	}
+	// what's left has to be drawn and played without falling over
+	m.View()
+	m, _ = submit(m, "SLATE")
+	m.View()
+}
+
+func TestStartupNoticeTimedOnce(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.json")
+	if err := os.WriteFile(path, []byte("not stats"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m := newTestModel("CRANE", func(cfg *config) {
+		cfg.statsPath = path
+		cfg.dict, _ = words.New([]string{"CRANE"}, nil)
+	})
+	if len(m.notices) != 1 {
+		t.Fatalf("Notices = %+v, want one about the stats", m.notices)
+	}
+
+	// Init can't keep track of a timer, so it leaves it to Update. Everything
+	// it does start is quick, unlike a notice timer.
+	batch, ok := m.Init()().(tea.BatchMsg)
+	if !ok {
+		t.Fatal("Init should return a batch of commands, but didn't")
+	}
+	msgs := make(chan tea.Msg, len(batch))
+	for _, cmd := range batch {
+		go func(cmd tea.Cmd) { msgs <- cmd() }(cmd)
+	}
+	started := false
+	for range batch {
+		select {
+		case msg := <-msgs:
+			_, isStarted := msg.(msgStarted)
+			started = started || isStarted
+		case <-time.After(time.Second):
+			t.Fatal("Init started a notice timer")
+		}
+	}
+	if !started {
+		t.Fatal("Init should have sent msgStarted, but didn't")
+	}
+
+	m, cmd := update(m, msgStarted{})
+	if cmd == nil || m.noticeTimer != m.notices[0].id {
+		t.Errorf("Startup notice should be timed once the program starts, but isn't")
+	}
+	if _, cmd := update(m, msgStarted{}); cmd != nil {
+		t.Errorf("Startup notice shouldn't be timed twice")
+	}
+}
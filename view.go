@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/bianxm/godle/wordle"
 
@@ -9,59 +10,115 @@ import (
 )
 
 func (m model) View() string {
+	title := m.renderTitle()
 	status := m.renderStatus()
 	grid := m.renderRows()
+	hint := m.renderHint()
 	debug := m.renderDebug()
 	ab := m.renderAlphabet()
+	if m.mode == modeMulti {
+		grid = m.renderMultiBoards()
+		ab = m.renderMultiAlphabet()
+	}
+	if m.mode == modeRace {
+		if opponents := m.renderOpponents(); opponents != "" {
+			grid = lipgloss.JoinHorizontal(lipgloss.Center, grid, "   ", opponents)
+		}
+	}
+	if m.gameOver && m.mode == modeClassic {
+		// the keyboard isn't any use once the game is over
+		ab = m.renderStats()
+	}
 
 	game := lipgloss.JoinVertical(
 		lipgloss.Center,
+		title,
 		status,
 		grid,
-		debug,
+		hint,
 		ab,
 	)
+	if debug != "" {
+		game = lipgloss.JoinHorizontal(lipgloss.Center, game, "  ", debug)
+	}
 
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, game)
 }
 
-const (
-	colorPrimary   = lipgloss.Color("#d7dadc")
-	colorSecondary = lipgloss.Color("#626262")
-	colorSeparator = lipgloss.Color("#9c9c9c")
-	colorYellow    = lipgloss.Color("#b59f3b")
-	colorGreen     = lipgloss.Color("#538d4e")
-)
-
-func statusToColor(ls wordle.LetterStatus) lipgloss.Color {
-	switch ls {
-	case wordle.None:
-		return colorPrimary
-	case wordle.Absent:
-		return colorSecondary
-	case wordle.Present:
-		return colorYellow
-	case wordle.Correct:
-		return colorGreen
-	default:
-		panic("invalid letter status")
+func (m *model) renderTitle() string {
+	title := "godle (free play)"
+	if m.mode == modeAssistant {
+		title = "godle assistant"
+	} else if m.mode == modeReverse {
+		title = "godle reverse"
+	} else if m.mode == modeAbsurd {
+		title = "godle absurd"
+	} else if m.mode == modeMulti {
+		title = fmt.Sprintf("godle ×%d (%d solved)", len(m.multi.Boards), m.multi.Solved())
+	} else if m.mode == modeRace {
+		title = fmt.Sprintf("godle race (%s)", m.race.room)
+	} else if m.ws.Daily != nil {
+		title = fmt.Sprintf("godle #%d", m.ws.Daily.Number)
 	}
+	return m.theme.newStyle().Bold(true).Foreground(m.theme.Text).Render(title)
 }
 
-func (m *model) renderDebug() string {
-	ws := m.ws
-	return lipgloss.
-		NewStyle().
-		Foreground(colorPrimary).
-		Render(fmt.Sprintf("[DEBUG] Correct word: %s", string(ws.Word[:])))
+func (m *model) renderHint() string {
+	if len(m.suggestions) > 0 && !m.gameOver {
+		return m.theme.newStyle().Foreground(m.theme.Text).Align(lipgloss.Center).Render(fmt.Sprintf(
+			"Did you mean %s?\nPress TAB for %s",
+			orList(m.suggestions),
+			m.suggestions[0],
+		))
+	}
+	if m.mode == modeRace {
+		if m.race.result != nil && !m.race.racing {
+			return m.renderStandings()
+		}
+		return ""
+	}
+	if m.gameOver || m.choosing || m.mode == modeMulti {
+		return ""
+	}
+	if m.mode == modeReverse {
+		return m.theme.newStyle().Foreground(m.theme.Muted).Render(fmt.Sprintf(
+			"I think there are %d words left",
+			m.reverseRemaining,
+		))
+	}
+	if m.mode == modeAbsurd && m.hint == nil {
+		return m.theme.newStyle().Foreground(m.theme.Muted).Align(lipgloss.Center).Render(fmt.Sprintf(
+			"%d words still possible\nPress ? for a hint",
+			m.adversary.Remaining(),
+		))
+	}
+	if m.hint == nil {
+		return m.theme.newStyle().Foreground(m.theme.Muted).Render("Press ? for a hint")
+	}
+	if m.hint.remaining == 0 {
+		return m.theme.newStyle().Foreground(m.theme.Text).Render("No possible answers left")
+	}
+	words := make([]string, len(m.hint.suggestions))
+	for i, s := range m.hint.suggestions {
+		words[i] = s.Word
+	}
+	return m.theme.newStyle().Foreground(m.theme.Text).Align(lipgloss.Center).Render(fmt.Sprintf(
+		"%d possible answers left\nTry %s",
+		m.hint.remaining,
+		strings.Join(words, ", "),
+	))
 }
 
 func (m *model) renderStatus() string {
-	return lipgloss.NewStyle().Foreground(colorPrimary).Render(m.status)
+	if len(m.notices) > 0 {
+		n := m.notices[0]
+		return m.theme.newStyle().Foreground(m.theme.severityColor(n.severity)).Render(n.text)
+	}
+	return m.theme.newStyle().Foreground(m.theme.Text).Render(m.status)
 }
 
-func renderLetterBox(letter string, color lipgloss.TerminalColor) string {
-	return lipgloss.NewStyle().
+func (t *Theme) renderLetterBox(letter string, color lipgloss.TerminalColor) string {
+	return t.newStyle().
 		Padding(0, 1).
 		Border(lipgloss.NormalBorder()).
 		BorderForeground(color).
@@ -70,19 +127,74 @@ func renderLetterBox(letter string, color lipgloss.TerminalColor) string {
 }
 
 func (m *model) renderAlphabet() string {
-	rows := [3]string{"QWERTYUIOP", "ASDFGHJKL", "ZXCVBNM"}
-	var rr [3]string
-	var letterBoxes [3][10]string
-	fmt.Printf("%+v", m.ws.Alphabet[byte('H')])
-	for i, chars := range rows {
+	rr := make([]string, len(m.keyboard.rows))
+	for i, chars := range m.keyboard.rows {
+		letterBoxes := make([]string, len(chars))
 		for j, c := range chars {
-			letterBoxes[i][j] = renderLetterBox(string(c), statusToColor(m.ws.Alphabet[byte(c)]))
+			letterBoxes[j] = m.theme.renderTile(string(c), m.ws.Alphabet[byte(c)])
 		}
+		rr[i] = renderRowOfBoxes(letterBoxes)
 	}
-	for i := 0; i < 3; i++ {
-		rr[i] = renderRowOfBoxes(letterBoxes[i][:])
+	return lipgloss.JoinVertical(lipgloss.Center, rr...)
+}
+
+// statsBarWidth is the width of the longest guess distribution bar.
+const statsBarWidth = 30
+
+func (m *model) renderStats() string {
+	st := m.stats
+	numbers := []struct {
+		value int
+		label string
+	}{
+		{st.Played, "Played"},
+		{st.WinPercentage(), "Win %"},
+		{st.CurrentStreak, "Current\nStreak"},
+		{st.MaxStreak, "Max\nStreak"},
 	}
-	return lipgloss.JoinVertical(lipgloss.Center, rr[:]...)
+	cells := make([]string, len(numbers))
+	for i, n := range numbers {
+		cells[i] = m.theme.newStyle().
+			Width(9).
+			Align(lipgloss.Center).
+			Foreground(m.theme.Text).
+			Render(fmt.Sprintf("%d\n%s", n.value, n.label))
+	}
+
+	most := 1
+	for _, n := range st.Distribution {
+		if n > most {
+			most = n
+		}
+	}
+	bars := make([]string, m.ws.Rules.MaxGuesses)
+	for i := range bars {
+		guesses := i + 1
+		n := st.Distribution[guesses]
+		bar := m.theme.newStyle().
+			Width(1 + n*statsBarWidth/most).
+			Align(lipgloss.Right).
+			Background(m.theme.Muted).
+			Foreground(m.theme.TileText)
+		if m.ws.IsWordGuessed() && m.ws.CurrGuess == guesses {
+			bar = bar.Background(m.theme.Correct).Bold(m.theme.Marks)
+		}
+		if m.theme.Marks {
+			// without colours, the bars are reversed text
+			bar = bar.Reverse(true)
+		}
+		bars[i] = fmt.Sprintf("%d %s", guesses, bar.Render(fmt.Sprint(n)))
+	}
+
+	title := m.theme.newStyle().Bold(true).Foreground(m.theme.Text)
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		title.Render("STATISTICS"),
+		lipgloss.JoinHorizontal(lipgloss.Top, cells...),
+		"",
+		title.Render("GUESS DISTRIBUTION"),
+		lipgloss.JoinVertical(lipgloss.Left, bars...),
+	)
 }
 
 func renderRowOfBoxes(boxes []string) string {
@@ -90,38 +202,87 @@ func renderRowOfBoxes(boxes []string) string {
 }
 
 func (m *model) renderRows() string {
-	var rows [wordle.MaxGuesses]string
 	ws := m.ws
-	for i, g := range ws.Guesses {
-		if i < ws.CurrGuess {
-			rows[i] = m.renderPastGuess(g)
-		} else if i == ws.CurrGuess {
-			rows[i] = m.renderActiveGuess()
+	n, first := ws.Rules.MaxGuesses, 0
+	if n == 0 {
+		// with unlimited guesses, show the guesses so far and the row being
+		// typed, scrolling the oldest guesses away once they don't fit
+		n = ws.CurrGuess + 1
+		if ws.ShouldEndGame() {
+			n = ws.CurrGuess
+		}
+		if fit := m.visibleRows(); n > fit {
+			first = n - fit
+		}
+	}
+	rows := make([]string, 0, n-first)
+	for i := first; i < n; i++ {
+		var row string
+		if i < ws.CurrGuess && m.animating(animFlip, i) {
+			row = m.renderFlippingGuess(ws.Guesses[i])
+		} else if i < ws.CurrGuess && m.animating(animBounce, i) {
+			row = m.renderBouncingGuess(ws.Guesses[i])
+		} else if i < ws.CurrGuess {
+			row = m.renderPastGuess(ws.Guesses[i])
+		} else if i == ws.CurrGuess && m.marking {
+			row = m.renderMarkingGuess()
+		} else if i == ws.CurrGuess && (m.mode != modeReverse || m.choosing) {
+			row = m.renderActiveGuess()
 		} else {
-			rows[i] = m.renderFutureGuess()
+			row = m.renderFutureGuess()
 		}
+		rows = append(rows, m.renderRowOffset(i, row))
 	}
 	return lipgloss.JoinVertical(lipgloss.Left, rows[:]...)
 }
 
+// visibleRows returns how many rows of guesses fit on the screen alongside
+// everything else.
+func (m *model) visibleRows() int {
+	if m.height == 0 {
+		return wordle.DefaultMaxGuesses
+	}
+	// the title, status, hint and keyboard take up about 16 lines, and each
+	// row of boxes takes 3
+	if fit := (m.height - 16) / 3; fit > 1 {
+		return fit
+	}
+	return 1
+}
+
 func (m *model) renderPastGuess(g wordle.Guess) string {
-	var letterBoxes [wordle.WordSize]string
+	letterBoxes := make([]string, len(g))
 	for i, l := range g {
-		letterBoxes[i] = renderLetterBox(string(l.Char), statusToColor(l.Status))
+		letterBoxes[i] = m.theme.renderTile(string(l.Char), l.Status)
 	}
-	return renderRowOfBoxes(letterBoxes[:])
+	return renderRowOfBoxes(letterBoxes)
+}
+
+func (m *model) renderMarkingGuess() string {
+	letterBoxes := make([]string, len(m.markGuess))
+	for i, l := range m.markGuess {
+		style := m.theme.statusStyle(l.Status).
+			Padding(0, 1).
+			Border(m.theme.statusBorder(l.Status)).
+			BorderForeground(m.theme.statusColor(l.Status))
+		if i == m.markCursor {
+			style = style.Border(lipgloss.ThickBorder()).Bold(true)
+		}
+		letterBoxes[i] = style.Render(string(l.Char))
+	}
+	return renderRowOfBoxes(letterBoxes)
 }
 
 func (m *model) renderFutureGuess() string {
-	var letterBoxes [wordle.WordSize]string
-	for i := 0; i < wordle.WordSize; i++ {
-		letterBoxes[i] = renderLetterBox(" ", colorPrimary)
+	letterBoxes := make([]string, m.ws.Rules.WordSize)
+	for i := range letterBoxes {
+		letterBoxes[i] = m.theme.renderLetterBox(" ", m.theme.Text)
 	}
-	return renderRowOfBoxes(letterBoxes[:])
+	return renderRowOfBoxes(letterBoxes)
 }
 
 func (m *model) renderActiveGuess() string {
-	var letterBoxes [wordle.WordSize]string
+	letterBoxes := make([]string, len(m.activeGuess))
 	for i, char := range m.activeGuess {
 		var letter string
 		if i < m.cursor {
@@ -132,8 +293,20 @@ func (m *model) renderActiveGuess() string {
 			letter = " "
 		}
 
-		letterBoxes[i] = renderLetterBox(letter, colorPrimary)
+		color := m.theme.Text
+		if m.rowError {
+			color = m.theme.Error
+		}
+		letterBoxes[i] = m.theme.renderLetterBox(letter, color)
 	}
 
-	return renderRowOfBoxes(letterBoxes[:])
+	return renderRowOfBoxes(letterBoxes)
+}
+
+// orList lists words as "A", "A or B", or "A, B or C".
+func orList(words []string) string {
+	if len(words) == 1 {
+		return words[0]
+	}
+	return strings.Join(words[:len(words)-1], ", ") + " or " + words[len(words)-1]
 }
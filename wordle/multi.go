@@ -0,0 +1,98 @@
+package wordle
+
+import (
+	"errors"
+)
+
+// MultiState plays several boards at once, Dordle or Quordle style. Every
+// guess goes to each board that hasn't been solved yet, and solved boards stay
+// as they were.
+type MultiState struct {
+	Boards []*WordleState
+	// CurrGuess is the number of guesses made so far.
+	CurrGuess int
+}
+
+// MultiMaxGuesses returns the usual guess limit for n boards, which is five
+// more than the number of boards.
+func MultiMaxGuesses(n int) int {
+	return n + 5
+}
+
+// NewMultiState starts a board for each word, all under the same rules.
+func NewMultiState(words []string, rules Rules) (*MultiState, error) {
+	if len(words) == 0 {
+		return nil, errors.New("Need at least one board")
+	}
+	ms := &MultiState{Boards: make([]*WordleState, len(words))}
+	for i, word := range words {
+		if err := rules.CheckWord(word); err != nil {
+			return nil, err
+		}
+		ws := NewWordleState(word, rules)
+		ms.Boards[i] = &ws
+	}
+	return ms, nil
+}
+
+// Rules returns the rules the boards are played under.
+func (ms *MultiState) Rules() Rules {
+	return ms.Boards[0].Rules
+}
+
+// AppendGuess plays word on every unsolved board. The guess is checked
+// against all of them first, so it's either added to all of them or none.
+func (ms *MultiState) AppendGuess(word string) error {
+	if ms.ShouldEndGame() {
+		return &InvalidGuessError{Reason: ReasonMaxGuesses, Word: word}
+	}
+	for _, ws := range ms.Boards {
+		if ws.IsWordGuessed() {
+			continue
+		}
+		if err := ws.ValidateGuess(NewGuess(word)); err != nil {
+			return err
+		}
+	}
+
+	for _, ws := range ms.Boards {
+		if ws.IsWordGuessed() {
+			continue
+		}
+		g := NewGuess(word)
+		ws.Score(g)
+		if err := ws.AppendGuess(g); err != nil {
+			// ValidateGuess has already passed
+			return err
+		}
+	}
+	ms.CurrGuess++
+	return nil
+}
+
+// Solved returns the number of boards that have been solved.
+func (ms *MultiState) Solved() int {
+	n := 0
+	for _, ws := range ms.Boards {
+		if ws.IsWordGuessed() {
+			n++
+		}
+	}
+	return n
+}
+
+// IsWordGuessed reports whether every board has been solved.
+func (ms *MultiState) IsWordGuessed() bool {
+	return ms.Solved() == len(ms.Boards)
+}
+
+// ShouldEndGame returns true once every board is solved, or once an unsolved
+// board has run out of guesses.
+func (ms *MultiState) ShouldEndGame() bool {
+	for _, ws := range ms.Boards {
+		if ws.ShouldEndGame() && !ws.IsWordGuessed() {
+			return true
+		}
+	}
+	return ms.IsWordGuessed()
+}
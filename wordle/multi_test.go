@@ -0,0 +1,63 @@
+package wordle
+
+import (
+	"testing"
+)
+
+func TestMultiState(t *testing.T) {
+	rules := DefaultRules()
+	rules.MaxGuesses = MultiMaxGuesses(2)
+	ms, err := NewMultiState([]string{"CHARM", "TRAIN"}, rules)
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+
+	if err := ms.AppendGuess("HHHHH"); err == nil {
+		t.Errorf("Request went through, but expecting error 'Invalid word'")
+	}
+	if ms.CurrGuess != 0 || ms.Boards[0].CurrGuess != 0 {
+		t.Errorf("Invalid guess was added")
+	}
+
+	for _, word := range []string{"CHAIR", "CHARM", "TRAIN"} {
+		if err := ms.AppendGuess(word); err != nil {
+			t.Fatalf("appendGuess(%s) returned error: %s", word, err)
+		}
+	}
+	if ms.CurrGuess != 3 {
+		t.Errorf("CurrGuess = %d, want 3", ms.CurrGuess)
+	}
+	// the first board was solved by the second guess, so it froze there
+	if ms.Boards[0].CurrGuess != 2 || ms.Boards[1].CurrGuess != 3 {
+		t.Errorf(
+			"board guesses = %d, %d; want 2, 3",
+			ms.Boards[0].CurrGuess,
+			ms.Boards[1].CurrGuess,
+		)
+	}
+	if !ms.IsWordGuessed() || !ms.ShouldEndGame() {
+		t.Errorf("Should be solved after guessing both words")
+	}
+}
+
+func TestMultiStateOutOfGuesses(t *testing.T) {
+	rules := Rules{WordSize: 5, MaxGuesses: 2}
+	ms, _ := NewMultiState([]string{"CHARM", "TRAIN"}, rules)
+	ms.AppendGuess("CHARM")
+	if ms.ShouldEndGame() {
+		t.Errorf("Shouldn't end game with a guess left")
+	}
+	ms.AppendGuess("YIELD")
+	if !ms.ShouldEndGame() || ms.IsWordGuessed() {
+		t.Errorf("Should end game unsolved after running out of guesses")
+	}
+	if ms.Solved() != 1 {
+		t.Errorf("Solved() = %d, want 1", ms.Solved())
+	}
+}
+
+func TestNewMultiStateWordLength(t *testing.T) {
+	if _, err := NewMultiState([]string{"CHARM", "CAT"}, DefaultRules()); err == nil {
+		t.Errorf("Word shorter than the rules allow should be an error, but isn't")
+	}
+}
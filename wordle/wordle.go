@@ -1,16 +1,62 @@
 package wordle
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 
 	words "github.com/bianxm/godle/words"
 )
 
 const (
-	MaxGuesses = 6
-	WordSize   = 5
+	DefaultMaxGuesses = 6
+	DefaultWordSize   = 5
 )
 
+// Rules configures the shape of a game.
+type Rules struct {
+	WordSize int
+	// MaxGuesses limits the number of guesses. Zero means unlimited.
+	MaxGuesses int
+	// HardMode requires every revealed hint to be used in later guesses.
+	HardMode bool
+}
+
+// DefaultRules returns the rules of the classic game: five letter words and
+// six guesses.
+func DefaultRules() Rules {
+	return Rules{
+		WordSize:   DefaultWordSize,
+		MaxGuesses: DefaultMaxGuesses,
+	}
+}
+
+// Validate returns an error if the rules can't be used to play a game.
+func (r Rules) Validate() error {
+	if r.WordSize < 1 {
+		return errors.New("Word size must be positive")
+	}
+	if r.MaxGuesses < 0 {
+		return errors.New("Max guesses can't be negative")
+	}
+	return nil
+}
+
+// CheckWord returns an error unless word has as many letters as the rules'
+// words, all of them upper case A to Z.
+func (r Rules) CheckWord(word string) error {
+	if len(word) != r.WordSize {
+		return fmt.Errorf("Word %s doesn't have %d letters", word, r.WordSize)
+	}
+	for i := 0; i < len(word); i++ {
+		if word[i] < 'A' || word[i] > 'Z' {
+			return fmt.Errorf("Word %s has letters other than A to Z", word)
+		}
+	}
+	return nil
+}
+
 type LetterStatus int
 
 const (
@@ -20,33 +66,131 @@ const (
 	Correct
 )
 
+var letterStatusNames = [...]string{"None", "Absent", "Present", "Correct"}
+
+func (ls LetterStatus) String() string {
+	if ls < 0 || int(ls) >= len(letterStatusNames) {
+		return fmt.Sprintf("LetterStatus(%d)", int(ls))
+	}
+	return letterStatusNames[ls]
+}
+
+// MarshalText encodes the status by name, so saved games stay readable.
+func (ls LetterStatus) MarshalText() ([]byte, error) {
+	if ls < 0 || int(ls) >= len(letterStatusNames) {
+		return nil, fmt.Errorf("Invalid letter status %d", int(ls))
+	}
+	return []byte(ls.String()), nil
+}
+
+func (ls *LetterStatus) UnmarshalText(b []byte) error {
+	for i, name := range letterStatusNames {
+		if string(b) == name {
+			*ls = LetterStatus(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("Invalid letter status %q", b)
+}
+
 // word - to be guessed
 // guesses - array max length 6 of player guesses. made up of:
 // guess - string of 6 letters
 // letter has state absent/ present/ correct
 
+// An Answerer gives feedback on guesses. It decides what the answer is, which
+// it doesn't have to settle on until the game is over.
+type Answerer interface {
+	// Score sets the status of each letter of g.
+	Score(g Guess)
+}
+
+// FixedWord is an Answerer for a word that was picked before the game.
+type FixedWord []byte
+
+func (w FixedWord) Score(g Guess) {
+	g.UpdateLettersWithWord(w)
+}
+
+// Puzzle identifies the daily puzzle a game is being played for.
+type Puzzle = words.Puzzle
+
 type WordleState struct {
-	Word      [WordSize]byte
-	Guesses   [MaxGuesses]Guess
+	Rules Rules
+	// Daily is set when the game is a daily puzzle, and nil in free play.
+	Daily *Puzzle
+	// Word is the answer, if it was picked before the game. Guesses are
+	// scored by Answerer, which is nil in assistant games, where the player
+	// scores them.
+	Word      []byte
+	Answerer  Answerer
+	Guesses   []Guess
 	CurrGuess int
 	Alphabet  map[byte]LetterStatus
+	// Dict is the dictionary guesses are checked against. If it's nil,
+	// that's the built-in dictionary for the word size. It isn't saved with
+	// the game.
+	Dict *words.Dictionary
+}
+
+// Dictionary returns the dictionary guesses are checked against, or nil if
+// there isn't one for the word size.
+func (ws *WordleState) Dictionary() *words.Dictionary {
+	if ws.Dict != nil {
+		return ws.Dict
+	}
+	d, _ := words.Builtin(ws.Rules.WordSize)
+	return d
 }
 
-type Guess [WordSize]letter
+type Guess []letter
 
 func (g Guess) string() string {
-	// var w [wordSize]byte
 	str := ""
 	for _, l := range g {
-		// 	w[i] = l.char
 		if 'A' <= l.Char && l.Char <= 'Z' {
 			str += string(l.Char)
 		}
 	}
-	// return string(w[:])
 	return str
 }
 
+// jsonGuess is how a Guess is stored as JSON: the word, and the status of each
+// of its letters.
+type jsonGuess struct {
+	Word     string
+	Statuses []LetterStatus
+}
+
+func (g Guess) MarshalJSON() ([]byte, error) {
+	jg := jsonGuess{Statuses: make([]LetterStatus, len(g))}
+	for i, l := range g {
+		jg.Word += string(l.Char)
+		jg.Statuses[i] = l.Status
+	}
+	return json.Marshal(jg)
+}
+
+func (g *Guess) UnmarshalJSON(b []byte) error {
+	var jg jsonGuess
+	if err := json.Unmarshal(b, &jg); err != nil {
+		return err
+	}
+	if len(jg.Word) != len(jg.Statuses) {
+		return fmt.Errorf("Guess %s has %d statuses", jg.Word, len(jg.Statuses))
+	}
+	*g = NewGuess(jg.Word)
+	for i, ls := range jg.Statuses {
+		(*g)[i].Status = ls
+	}
+	return nil
+}
+
+// Word returns the letters of the guess as a string.
+func (g Guess) Word() string {
+	return g.string()
+}
+
 type letter struct {
 	Char   byte
 	Status LetterStatus
@@ -60,15 +204,121 @@ type letter struct {
 // 	}
 // }
 
-func NewWordleState(word string) WordleState {
-	w := WordleState{Alphabet: make(map[byte]LetterStatus)}
-	w.Word = [WordSize]byte([]byte(word[:WordSize]))
+// NewWordleState starts a game for word under the given rules. Only the first
+// rules.WordSize letters of word are used, and it panics if there aren't that
+// many, so words from anywhere a dictionary hasn't already checked should go
+// through Rules.CheckWord first.
+func NewWordleState(word string, rules Rules) WordleState {
+	w := WordleState{
+		Rules:    rules,
+		Word:     []byte(word[:rules.WordSize]),
+		Guesses:  make([]Guess, 0, rules.MaxGuesses),
+		Alphabet: make(map[byte]LetterStatus),
+	}
+	w.Answerer = FixedWord(w.Word)
+	for c := 'A'; c <= 'Z'; c++ {
+		w.Alphabet[byte(c)] = None
+	}
+	return w
+}
+
+// jsonWordleState is how a WordleState is stored as JSON. The word and the
+// alphabet's keys are stored as strings rather than numbers.
+type jsonWordleState struct {
+	Rules     Rules
+	Daily     *Puzzle `json:",omitempty"`
+	Word      string
+	Guesses   []Guess
+	CurrGuess int
+	Alphabet  map[string]LetterStatus
+}
+
+func (ws WordleState) MarshalJSON() ([]byte, error) {
+	js := jsonWordleState{
+		Rules:     ws.Rules,
+		Daily:     ws.Daily,
+		Word:      string(ws.Word),
+		Guesses:   ws.Guesses,
+		CurrGuess: ws.CurrGuess,
+		Alphabet:  make(map[string]LetterStatus, len(ws.Alphabet)),
+	}
+	for c, ls := range ws.Alphabet {
+		js.Alphabet[string(c)] = ls
+	}
+	return json.Marshal(js)
+}
+
+func (ws *WordleState) UnmarshalJSON(b []byte) error {
+	var js jsonWordleState
+	if err := json.Unmarshal(b, &js); err != nil {
+		return err
+	}
+	if err := js.Rules.Validate(); err != nil {
+		return err
+	}
+	// assistant games don't have a word
+	if js.Word != "" {
+		if err := js.Rules.CheckWord(js.Word); err != nil {
+			return err
+		}
+	}
+	if js.CurrGuess != len(js.Guesses) || (js.Rules.MaxGuesses > 0 && js.CurrGuess > js.Rules.MaxGuesses) {
+		return fmt.Errorf("Invalid guess count %d", js.CurrGuess)
+	}
+	for _, g := range js.Guesses {
+		if err := js.Rules.CheckWord(g.Word()); err != nil {
+			return err
+		}
+	}
+
+	if js.Word == "" {
+		*ws = NewAssistantState(js.Rules)
+	} else {
+		*ws = NewWordleState(js.Word, js.Rules)
+	}
+	ws.Daily = js.Daily
+	ws.Guesses = append(ws.Guesses, js.Guesses...)
+	ws.CurrGuess = js.CurrGuess
+	for c, ls := range js.Alphabet {
+		if len(c) != 1 {
+			return fmt.Errorf("Invalid alphabet letter %q", c)
+		}
+		ws.Alphabet[c[0]] = ls
+	}
+	return nil
+}
+
+// NewAssistantState starts a game without a known word, for helping with a
+// game played somewhere else. Guesses have to be given their feedback before
+// they're appended, instead of being scored with UpdateLettersWithWord.
+func NewAssistantState(rules Rules) WordleState {
+	w := WordleState{
+		Rules:    rules,
+		Guesses:  make([]Guess, 0, rules.MaxGuesses),
+		Alphabet: make(map[byte]LetterStatus),
+	}
 	for c := 'A'; c <= 'Z'; c++ {
 		w.Alphabet[byte(c)] = None
 	}
 	return w
 }
 
+// NewAnswererState starts a game where a guess's feedback is decided by a,
+// rather than by a word picked up front.
+func NewAnswererState(a Answerer, rules Rules) WordleState {
+	w := NewAssistantState(rules)
+	w.Answerer = a
+	return w
+}
+
+// Score gives g its feedback from the game's Answerer. It does nothing in
+// assistant games, where the player gives the feedback.
+func (ws *WordleState) Score(g Guess) {
+	if ws.Answerer != nil {
+		ws.Answerer.Score(g)
+	}
+}
+
 func newLetter(b byte) letter {
 	return letter{Char: b}
 }
@@ -76,18 +326,19 @@ func newLetter(b byte) letter {
 func NewGuess(s string) Guess {
 	// loop over each letter in string
 	// convert to letter structs
-	var g Guess
-	for i, l := range s {
-		g[i] = newLetter(byte(l))
+	g := make(Guess, len(s))
+	for i := 0; i < len(s); i++ {
+		g[i] = newLetter(s[i])
 	}
 	return g
 }
 
 // GAME LOGIC!
-func (g *Guess) UpdateLettersWithWord(word [WordSize]byte) {
+func (g Guess) UpdateLettersWithWord(word []byte) {
 	// updates status of the letters in the guess based on a word
-	// create a map of letter to count
-	lc := make(map[byte]int)
+	// count how many of each letter there are. this is a small array rather
+	// than a map because the solver calls this millions of times
+	var lc [256]uint8
 	for _, c := range word {
 		lc[c] += 1
 	}
@@ -95,9 +346,11 @@ func (g *Guess) UpdateLettersWithWord(word [WordSize]byte) {
 	// FIRST iterate through all letters in g and
 	// check if word[i] is same as l.char -> correct
 	// and subtract from the count map
+	// (statuses from an earlier call are cleared, so a guess can be reused)
 	for i := range g {
 		l := &g[i]
-		if word[i] == l.Char {
+		l.Status = None
+		if i < len(word) && word[i] == l.Char {
 			l.Status = Correct
 			lc[l.Char] -= 1
 		}
@@ -116,19 +369,35 @@ func (g *Guess) UpdateLettersWithWord(word [WordSize]byte) {
 	}
 }
 
-func (ws *WordleState) AppendGuess(g Guess) error {
-	// return nil if added successfully
+// ValidateGuess returns the error AppendGuess would return for g, without
+// adding it.
+func (ws *WordleState) ValidateGuess(g Guess) error {
 	// error if: max guesses already reached, guess isn't long enough, guess isn't valid word
-	if ws.CurrGuess >= MaxGuesses {
-		return errors.New("Max guesses reached")
+	word := g.string()
+	if ws.Rules.MaxGuesses > 0 && ws.CurrGuess >= ws.Rules.MaxGuesses {
+		return &InvalidGuessError{Reason: ReasonMaxGuesses, Word: word}
+	}
+
+	if len(word) != ws.Rules.WordSize {
+		return &InvalidGuessError{Reason: ReasonLength, Word: word, Expected: ws.Rules.WordSize, Actual: len(word)}
+	}
+
+	if !ws.Dictionary().Contains(word) {
+		return &InvalidGuessError{Reason: ReasonNotInDictionary, Word: word}
 	}
 
-	if len(g.string()) != WordSize {
-		return errors.New("Invalid guess length")
+	if ws.Rules.HardMode {
+		if err := ws.checkHardMode(g); err != nil {
+			return &InvalidGuessError{Reason: ReasonHardMode, Word: word, HardMode: err}
+		}
 	}
+	return nil
+}
 
-	if !words.IsWord(g.string()) {
-		return errors.New("Invalid word")
+func (ws *WordleState) AppendGuess(g Guess) error {
+	// return nil if added successfully
+	if err := ws.ValidateGuess(g); err != nil {
+		return err
 	}
 
 	// mutate Alphabet to reflect new letters guessed
@@ -142,23 +411,234 @@ func (ws *WordleState) AppendGuess(g Guess) error {
 		// fmt.Printf("%c: %d %d\n", g[i].Char, ws.Alphabet[g[i].Char], g[i].Status)
 	}
 
-	ws.Guesses[ws.CurrGuess] = g
+	ws.Guesses = append(ws.Guesses, g)
 	ws.CurrGuess++
 	return nil
 }
 
+// The errors AppendGuess returns for guesses it turns down, one for each
+// Reason. They're for comparing with errors.Is; the errors themselves are
+// *InvalidGuessError, which has the details.
+var (
+	ErrMaxGuesses      = errors.New("Max guesses reached")
+	ErrInvalidLength   = errors.New("Invalid guess length")
+	ErrNotInDictionary = errors.New("Invalid word")
+	ErrHardMode        = errors.New("Guess doesn't use every hint")
+)
+
+// Reason is why a guess was turned down.
+type Reason int
+
+const (
+	// ReasonMaxGuesses means there are no guesses left.
+	ReasonMaxGuesses Reason = iota + 1
+	// ReasonLength means the guess isn't as long as the word.
+	ReasonLength
+	// ReasonNotInDictionary means the guess isn't an allowed word.
+	ReasonNotInDictionary
+	// ReasonHardMode means the guess left out a hint in hard mode.
+	ReasonHardMode
+)
+
+var reasonErrors = map[Reason]error{
+	ReasonMaxGuesses:      ErrMaxGuesses,
+	ReasonLength:          ErrInvalidLength,
+	ReasonNotInDictionary: ErrNotInDictionary,
+	ReasonHardMode:        ErrHardMode,
+}
+
+var reasonNames = map[Reason]string{
+	ReasonMaxGuesses:      "max_guesses",
+	ReasonLength:          "length",
+	ReasonNotInDictionary: "not_in_dictionary",
+	ReasonHardMode:        "hard_mode",
+}
+
+func (r Reason) String() string {
+	if name, ok := reasonNames[r]; ok {
+		return name
+	}
+	return fmt.Sprintf("Reason(%d)", int(r))
+}
+
+// InvalidGuessError is returned by AppendGuess and ValidateGuess when a guess
+// can't be played. It matches the Err variable for its Reason with errors.Is,
+// and its HardModeError, if it has one, with errors.As.
+type InvalidGuessError struct {
+	Reason Reason
+	// Word is the guess that was turned down.
+	Word string
+	// Expected and Actual are the lengths of the word and the guess, for
+	// ReasonLength.
+	Expected int
+	Actual   int
+	// HardMode is the hint that was left out, for ReasonHardMode.
+	HardMode *HardModeError
+}
+
+func (e *InvalidGuessError) Error() string {
+	if e.HardMode != nil {
+		return e.HardMode.Error()
+	}
+	if err, ok := reasonErrors[e.Reason]; ok {
+		return err.Error()
+	}
+	return "Invalid guess"
+}
+
+func (e *InvalidGuessError) Is(target error) bool {
+	return target != nil && reasonErrors[e.Reason] == target
+}
+
+func (e *InvalidGuessError) Unwrap() error {
+	if e.HardMode == nil {
+		// a nil *HardModeError would be a non-nil error
+		return nil
+	}
+	return e.HardMode
+}
+
+// HardModeError says which hint a guess in hard mode left out. It's the
+// HardMode of the InvalidGuessError AppendGuess returns.
+type HardModeError struct {
+	// Letter is the hinted letter that was left out.
+	Letter byte
+	// Position is the index the letter is known to be at, or -1 if the letter
+	// only has to appear somewhere in the guess.
+	Position int
+}
+
+func (e *HardModeError) Error() string {
+	if e.Position >= 0 {
+		return fmt.Sprintf("%s letter must be %c", ordinal(e.Position+1), e.Letter)
+	}
+	return fmt.Sprintf("Guess must contain %c", e.Letter)
+}
+
+func ordinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
+
+func (ws *WordleState) checkHardMode(g Guess) *HardModeError {
+	// green letters have to stay in place
+	for _, prev := range ws.Guesses {
+		for i, l := range prev {
+			if l.Status == Correct && g[i].Char != l.Char {
+				return &HardModeError{Letter: l.Char, Position: i}
+			}
+		}
+	}
+	// every hinted letter has to show up at least as many times as any
+	// earlier guess revealed it
+	required := make(map[byte]int)
+	for _, prev := range ws.Guesses {
+		hinted := make(map[byte]int)
+		for _, l := range prev {
+			if l.Status == Correct || l.Status == Present {
+				hinted[l.Char]++
+			}
+		}
+		for c, n := range hinted {
+			if n > required[c] {
+				required[c] = n
+			}
+		}
+	}
+	have := make(map[byte]int)
+	for _, l := range g {
+		have[l.Char]++
+	}
+	for _, prev := range ws.Guesses {
+		for _, l := range prev {
+			if have[l.Char] < required[l.Char] {
+				return &HardModeError{Letter: l.Char, Position: -1}
+			}
+		}
+	}
+	return nil
+}
+
 func (ws *WordleState) IsWordGuessed() bool {
 	// returns true if latest guess is the correct word
 	// check ws.guesses[currGuess-1].string() == ws.word
 	if ws.CurrGuess == 0 {
 		return false
 	}
-	return ws.Guesses[ws.CurrGuess-1].string() == string(ws.Word[:])
+	last := ws.Guesses[ws.CurrGuess-1]
+	if ws.Word == nil {
+		// without a word, the player has told us whether the guess was right
+		for _, l := range last {
+			if l.Status != Correct {
+				return false
+			}
+		}
+		return true
+	}
+	return last.string() == string(ws.Word)
 }
 
 func (ws *WordleState) ShouldEndGame() bool {
 	// return true if latest guess is correct
 	// or no more guesses are allowed
 
-	return ws.IsWordGuessed() || ws.CurrGuess >= MaxGuesses
+	return ws.IsWordGuessed() || (ws.Rules.MaxGuesses > 0 && ws.CurrGuess >= ws.Rules.MaxGuesses)
+}
+
+// Share formats a summary of the game that doesn't give away any letters: a
+// header like "godle #123 4/6" followed by one row of squares per guess. A
+// lost game is scored as X, and hard mode games are marked with an asterisk.
+// With ascii set, the squares are drawn as G, Y and - for terminals that
+// can't display emoji.
+func (ws *WordleState) Share(ascii bool) string {
+	squares := map[LetterStatus]string{
+		Correct: "🟩",
+		Present: "🟨",
+		Absent:  "⬛",
+		None:    "⬜",
+	}
+	if ascii {
+		squares = map[LetterStatus]string{
+			Correct: "G",
+			Present: "Y",
+			Absent:  "-",
+			None:    ".",
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("godle")
+	if ws.Daily != nil {
+		fmt.Fprintf(&b, " #%d", ws.Daily.Number)
+	}
+	score := "X"
+	if ws.IsWordGuessed() {
+		score = fmt.Sprint(ws.CurrGuess)
+	}
+	if ws.Rules.MaxGuesses > 0 {
+		fmt.Fprintf(&b, " %s/%d", score, ws.Rules.MaxGuesses)
+	} else {
+		fmt.Fprintf(&b, " %s", score)
+	}
+	if ws.Rules.HardMode {
+		b.WriteString("*")
+	}
+	b.WriteString("\n")
+
+	for _, g := range ws.Guesses[:ws.CurrGuess] {
+		b.WriteString("\n")
+		for _, l := range g {
+			b.WriteString(squares[l.Status])
+		}
+	}
+	return b.String()
 }
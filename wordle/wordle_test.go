@@ -1,6 +1,10 @@
 package wordle
 
 import (
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"reflect"
 	"testing"
 
 	words "github.com/bianxm/godle/words"
@@ -8,7 +12,7 @@ import (
 
 func TestNewWordleState(t *testing.T) {
 	word := "HELLOTHERE"
-	ws := NewWordleState(word)
+	ws := NewWordleState(word, DefaultRules())
 	wordleAsString := string(ws.Word[:])
 
 	if wordleAsString != word[:5] {
@@ -65,8 +69,7 @@ func TestNewGuess(t *testing.T) {
 
 func TestUpdateLettersWithWord(t *testing.T) {
 	guessWord := "LELOL"
-	var word [WordSize]byte
-	copy(word[:], "HELLO")
+	word := []byte("HELLO")
 	statuses := []LetterStatus{
 		Present,
 		Correct,
@@ -99,9 +102,11 @@ func TestUpdateLettersWithWord(t *testing.T) {
 }
 
 func TestAppendGuessMaxGuesses(t *testing.T) {
-	ws := NewWordleState("HELLO")
-	for i := 0; i < MaxGuesses; i++ {
-		word := words.GetWord()
+	ws := NewWordleState("HELLO", DefaultRules())
+	d, _ := words.Builtin(DefaultWordSize)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < ws.Rules.MaxGuesses; i++ {
+		word := d.Random(r)
 		// word := "LLLLL"
 		err := ws.AppendGuess(NewGuess(word))
 		// check currGuess = i+1
@@ -128,7 +133,8 @@ func TestAppendGuessMaxGuesses(t *testing.T) {
 		}
 	}
 	// add extra one: should fail
-	err := ws.AppendGuess(NewGuess(words.GetWord()))
+	word := d.Random(r)
+	err := ws.AppendGuess(NewGuess(word))
 	// t.Logf("%s", err)
 	if err == nil {
 		t.Errorf("Should error out for too many guesses, but didn't")
@@ -136,9 +142,8 @@ func TestAppendGuessMaxGuesses(t *testing.T) {
 }
 
 func TestAppendGuessAlphabetUpdate(t *testing.T) {
-	var w [WordSize]byte
-	copy(w[:], "HELLO")
-	ws := NewWordleState("HELLO")
+	w := []byte("HELLO")
+	ws := NewWordleState("HELLO", DefaultRules())
 	word := "HELPS"
 	g := NewGuess(word)
 	g.UpdateLettersWithWord(w)
@@ -166,26 +171,44 @@ func TestAppendGuessAlphabetUpdate(t *testing.T) {
 }
 
 func TestAppendGuessError(t *testing.T) {
-	ws := NewWordleState("HELLO")
+	ws := NewWordleState("HELLO", DefaultRules())
 
 	// invalid guess length
 	err1 := ws.AppendGuess(NewGuess("HI"))
-	// t.Logf("%s length %d", newGuess("HI").string(), len(newGuess("HI").string()))
-	t.Logf("%s", err1)
-	if err1 == nil {
-		t.Errorf("Request went through, but expecting error 'Invalid guess length'")
+	var ige *InvalidGuessError
+	if !errors.Is(err1, ErrInvalidLength) || !errors.As(err1, &ige) {
+		t.Fatalf("appendGuess(HI) = %v, want ErrInvalidLength", err1)
+	}
+	if ige.Reason != ReasonLength || ige.Word != "HI" || ige.Expected != 5 || ige.Actual != 2 {
+		t.Errorf("appendGuess(HI) = %+v, want the lengths of HI and the word", ige)
+	}
+	if err1.Error() != "Invalid guess length" {
+		t.Errorf("appendGuess(HI) = %q, want 'Invalid guess length'", err1)
 	}
 
 	// not a word
 	err2 := ws.AppendGuess(NewGuess("HHHHH"))
-	t.Logf("%s", err2)
-	if err2 == nil {
-		t.Errorf("Request went through, but expecting error 'Invalid word'")
+	if !errors.Is(err2, ErrNotInDictionary) || errors.Is(err2, ErrInvalidLength) {
+		t.Errorf("appendGuess(HHHHH) = %v, want ErrNotInDictionary", err2)
+	}
+	if err2.Error() != "Invalid word" {
+		t.Errorf("appendGuess(HHHHH) = %q, want 'Invalid word'", err2)
+	}
+
+	// out of guesses
+	for ws.CurrGuess < ws.Rules.MaxGuesses {
+		if err := ws.AppendGuess(NewGuess("CRANE")); err != nil {
+			t.Fatalf("Error: %s", err)
+		}
+	}
+	err3 := ws.AppendGuess(NewGuess("CRANE"))
+	if !errors.Is(err3, ErrMaxGuesses) || !errors.As(err3, &ige) || ige.Reason != ReasonMaxGuesses {
+		t.Errorf("appendGuess(CRANE) = %v, want ErrMaxGuesses", err3)
 	}
 }
 
 func TestIsWordGuessed(t *testing.T) {
-	ws := NewWordleState("HELLO")
+	ws := NewWordleState("HELLO", DefaultRules())
 	g := NewGuess("HELLO")
 	g.UpdateLettersWithWord(ws.Word)
 	if err := ws.AppendGuess(g); err != nil {
@@ -198,7 +221,7 @@ func TestIsWordGuessed(t *testing.T) {
 }
 
 func TestShouldEndGameCorrectGuess(t *testing.T) {
-	ws := NewWordleState("HELLO")
+	ws := NewWordleState("HELLO", DefaultRules())
 	g := NewGuess("HELLO")
 	g.UpdateLettersWithWord(ws.Word)
 	ws.AppendGuess(g)
@@ -209,8 +232,8 @@ func TestShouldEndGameCorrectGuess(t *testing.T) {
 }
 
 func TestShouldEndGameNoMoreGuesses(t *testing.T) {
-	ws := NewWordleState("HELLO")
-	for i := 0; i < MaxGuesses; i++ {
+	ws := NewWordleState("HELLO", DefaultRules())
+	for i := 0; i < ws.Rules.MaxGuesses; i++ {
 		g := NewGuess("YIELD")
 		g.UpdateLettersWithWord(ws.Word)
 		ws.AppendGuess(g)
@@ -225,6 +248,226 @@ func TestShouldEndGameNoMoreGuesses(t *testing.T) {
 	}
 }
 
+func TestCustomRules(t *testing.T) {
+	rules := Rules{WordSize: 7, MaxGuesses: 8}
+	ws := NewWordleState("PROBLEM", rules)
+	for i := 0; i < rules.MaxGuesses; i++ {
+		if ws.ShouldEndGame() {
+			t.Fatalf("Game ended after %d guesses, want %d", i, rules.MaxGuesses)
+		}
+		g := NewGuess("PROCESS")
+		g.UpdateLettersWithWord(ws.Word)
+		if err := ws.AppendGuess(g); err != nil {
+			t.Fatalf("appendGuess() returned error: %s", err)
+		}
+	}
+	if !ws.ShouldEndGame() {
+		t.Errorf("Should end game after %d guesses", rules.MaxGuesses)
+	}
+
+	// five letter guesses are too short for a seven letter game
+	ws = NewWordleState("PROBLEM", rules)
+	if err := ws.AppendGuess(NewGuess("HELLO")); err == nil {
+		t.Errorf("Request went through, but expecting error 'Invalid guess length'")
+	}
+}
+
+func TestCustomDictionary(t *testing.T) {
+	d, err := words.New([]string{"GODLE"}, []string{"GODLE", "BIANX"})
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	ws := NewWordleState("GODLE", DefaultRules())
+	ws.Dict = d
+	if err := ws.AppendGuess(NewGuess("CRANE")); err == nil {
+		t.Errorf("Should error out for a word outside the dictionary, but didn't")
+	}
+	g := NewGuess("BIANX")
+	ws.Score(g)
+	if err := ws.AppendGuess(g); err != nil {
+		t.Errorf("Error: %s", err)
+	}
+}
+
+func TestUpdateLettersWithWordFourLetters(t *testing.T) {
+	g := NewGuess("TOOL")
+	g.UpdateLettersWithWord([]byte("LOOT"))
+	statuses := []LetterStatus{Present, Correct, Correct, Present}
+	for i, l := range g {
+		if l.Status != statuses[i] {
+			t.Errorf(
+				"letter [%d] = %c, %s; want %s",
+				i,
+				l.Char,
+				statusToString(l.Status),
+				statusToString(statuses[i]),
+			)
+		}
+	}
+}
+
+func TestAppendGuessHardMode(t *testing.T) {
+	rules := DefaultRules()
+	rules.HardMode = true
+
+	tests := []struct {
+		guess string
+		want  string
+	}{
+		{"STORE", "3rd letter must be A"},
+		{"PLANT", "Guess must contain R"},
+		{"CHARM", ""},
+	}
+	for _, test := range tests {
+		ws := NewWordleState("CHARM", rules)
+		first := NewGuess("TRAIN")
+		first.UpdateLettersWithWord(ws.Word)
+		if err := ws.AppendGuess(first); err != nil {
+			t.Fatalf("Error: %s", err)
+		}
+
+		g := NewGuess(test.guess)
+		g.UpdateLettersWithWord(ws.Word)
+		err := ws.AppendGuess(g)
+		if test.want == "" {
+			if err != nil {
+				t.Errorf("appendGuess(%s) returned error: %s", test.guess, err)
+			}
+			continue
+		}
+		var hme *HardModeError
+		if !errors.As(err, &hme) || !errors.Is(err, ErrHardMode) {
+			t.Errorf("appendGuess(%s) = %v, want HardModeError", test.guess, err)
+			continue
+		}
+		if err.Error() != test.want {
+			t.Errorf("appendGuess(%s) = %q, want %q", test.guess, err, test.want)
+		}
+	}
+}
+
+func TestShare(t *testing.T) {
+	ws := NewWordleState("CHARM", DefaultRules())
+	ws.Daily = &Puzzle{Number: 123}
+	for _, word := range []string{"TRAIN", "CHARM"} {
+		g := NewGuess(word)
+		g.UpdateLettersWithWord(ws.Word)
+		if err := ws.AppendGuess(g); err != nil {
+			t.Fatalf("Error: %s", err)
+		}
+	}
+
+	want := "godle #123 2/6\n\n⬛🟨🟩⬛⬛\n🟩🟩🟩🟩🟩"
+	if got := ws.Share(false); got != want {
+		t.Errorf("Share(false) = %q, want %q", got, want)
+	}
+	want = "godle #123 2/6\n\n-YG--\nGGGGG"
+	if got := ws.Share(true); got != want {
+		t.Errorf("Share(true) = %q, want %q", got, want)
+	}
+}
+
+func TestShareLostHardMode(t *testing.T) {
+	rules := Rules{WordSize: 5, MaxGuesses: 1, HardMode: true}
+	ws := NewWordleState("CHARM", rules)
+	g := NewGuess("TRAIN")
+	g.UpdateLettersWithWord(ws.Word)
+	ws.AppendGuess(g)
+
+	want := "godle X/1*\n\n-YG--"
+	if got := ws.Share(true); got != want {
+		t.Errorf("Share(true) = %q, want %q", got, want)
+	}
+}
+
+func TestWordleStateJSON(t *testing.T) {
+	rules := DefaultRules()
+	rules.HardMode = true
+	ws := NewWordleState("CHARM", rules)
+	ws.Daily = &Puzzle{Number: 42, Date: "2023-07-12", Salt: "team"}
+	for _, word := range []string{"TRAIN", "CHAIR"} {
+		g := NewGuess(word)
+		g.UpdateLettersWithWord(ws.Word)
+		if err := ws.AppendGuess(g); err != nil {
+			t.Fatalf("Error: %s", err)
+		}
+	}
+
+	b, err := json.Marshal(ws)
+	if err != nil {
+		t.Fatalf("Marshal() returned error: %s", err)
+	}
+	t.Logf("%s", b)
+
+	var loaded WordleState
+	if err := json.Unmarshal(b, &loaded); err != nil {
+		t.Fatalf("Unmarshal() returned error: %s", err)
+	}
+	if !reflect.DeepEqual(ws, loaded) {
+		t.Errorf("loaded %+v, want %+v", loaded, ws)
+	}
+}
+
+func TestWordleStateJSONInvalid(t *testing.T) {
+	tests := []string{
+		`{"Rules":{"WordSize":5,"MaxGuesses":6},"Word":"HI","CurrGuess":0}`,
+		`{"Rules":{"WordSize":5,"MaxGuesses":6},"Word":"HELLO","CurrGuess":1}`,
+		`{"Rules":{"WordSize":5,"MaxGuesses":6},"Word":"HELLO","Guesses":[{"Word":"HELPS","Statuses":["Correct","Maybe","Absent","Absent","Absent"]}],"CurrGuess":1}`,
+		`{"Rules":{"WordSize":5,"MaxGuesses":6},"Word":"hello","CurrGuess":0}`,
+		`{"Rules":{"WordSize":5,"MaxGuesses":6},"Word":"HELL0","CurrGuess":0}`,
+		`{"Rules":{"WordSize":5,"MaxGuesses":6},"Word":"HELLO","Guesses":[{"Word":"HELPSS","Statuses":["Correct","Correct","Correct","Absent","Absent","Absent"]}],"CurrGuess":1}`,
+		`{"Rules":{"WordSize":5,"MaxGuesses":6},"Word":"HELLO","Guesses":[{"Word":"helps","Statuses":["Correct","Correct","Correct","Absent","Absent"]}],"CurrGuess":1}`,
+		`{"Rules":{"WordSize":5,"MaxGuesses":6},"Guesses":[{"Word":"HEL","Statuses":["Correct","Correct","Correct"]}],"CurrGuess":1}`,
+	}
+	for _, test := range tests {
+		var ws WordleState
+		if err := json.Unmarshal([]byte(test), &ws); err == nil {
+			t.Errorf("Unmarshal(%s) went through, but expecting error", test)
+		}
+	}
+}
+
+func TestAssistantState(t *testing.T) {
+	ws := NewAssistantState(DefaultRules())
+
+	g := NewGuess("TRAIN")
+	for i, ls := range []LetterStatus{Absent, Present, Correct, Absent, Absent} {
+		g[i].Status = ls
+	}
+	if err := ws.AppendGuess(g); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if ws.IsWordGuessed() {
+		t.Errorf("Should be false but returned true")
+	}
+	if ws.Alphabet['R'] != Present {
+		t.Errorf("Letter R: expecting Present, got %s", ws.Alphabet['R'])
+	}
+
+	g = NewGuess("CHARM")
+	for i := range g {
+		g[i].Status = Correct
+	}
+	if err := ws.AppendGuess(g); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if !ws.IsWordGuessed() {
+		t.Errorf("Should be true but returned false")
+	}
+
+	b, err := json.Marshal(ws)
+	if err != nil {
+		t.Fatalf("Marshal() returned error: %s", err)
+	}
+	var loaded WordleState
+	if err := json.Unmarshal(b, &loaded); err != nil {
+		t.Fatalf("Unmarshal() returned error: %s", err)
+	}
+	if !reflect.DeepEqual(ws, loaded) {
+		t.Errorf("loaded %+v, want %+v", loaded, ws)
+	}
+}
+
 // func TestInitAlphabet(t *testing.T) {
 // 	InitAlphabet()
 // 	t.Logf("%+v", Alphabet)
@@ -0,0 +1,198 @@
+package words
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+)
+
+// ErrBadChallenge is returned for a challenge or result code that's mistyped,
+// tampered with, or made with a different word list.
+var ErrBadChallenge = errors.New("not a valid code for this word list")
+
+// Challenge is a word picked by one player for another to guess, along with
+// the rules to play it under.
+type Challenge struct {
+	Word string
+	// MaxGuesses is the number of guesses allowed, or 0 for unlimited.
+	MaxGuesses int
+	HardMode   bool
+}
+
+// Result is how a player did at a challenge.
+type Result struct {
+	Solved bool
+	// Guesses is the number of guesses the player made.
+	Guesses int
+}
+
+const (
+	challengeVersion = 1
+	// tagSize is how many bytes of the HMAC are kept in a code.
+	tagSize = 5
+)
+
+// challengeKey signs codes. It isn't secret, since anyone can read it here,
+// but it stops a mistyped or edited code from being taken for a different
+// word, and keeps the word out of the code.
+var challengeKey = []byte("godle challenge v1")
+
+// codeEncoding is how codes are written: letters and digits that are easy to
+// read out, without padding.
+var codeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// EncodeChallenge returns an opaque code for c, which any valid guess in d can
+// be the word of. The code holds the word's place in the dictionary rather
+// than the word, so it can only be played with the same dictionary.
+func (d *Dictionary) EncodeChallenge(c Challenge) (string, error) {
+	word := strings.ToUpper(c.Word)
+	idx := sort.SearchStrings(d.guesses, word)
+	if idx == len(d.guesses) || d.guesses[idx] != word {
+		return "", fmt.Errorf("%s isn't in the word list", word)
+	}
+	if c.MaxGuesses < 0 || c.MaxGuesses > 255 {
+		return "", fmt.Errorf("can't make a challenge with %d guesses", c.MaxGuesses)
+	}
+	var flags byte
+	if c.HardMode {
+		flags |= 1
+	}
+	payload := []byte{
+		challengeVersion,
+		byte(d.length),
+		byte(idx >> 16), byte(idx >> 8), byte(idx),
+		byte(c.MaxGuesses),
+		flags,
+	}
+	return seal(payload, d.fingerprint()), nil
+}
+
+// ChallengeLength returns the length of the word in a challenge code, so the
+// right dictionary can be picked to decode it.
+func ChallengeLength(code string) (int, error) {
+	payload, _, err := open(code, 7)
+	if err != nil {
+		return 0, err
+	}
+	return int(payload[1]), nil
+}
+
+// DecodeChallenge returns the challenge in a code made by EncodeChallenge with
+// the same dictionary.
+func (d *Dictionary) DecodeChallenge(code string) (Challenge, error) {
+	payload, tag, err := open(code, 7)
+	if err != nil {
+		return Challenge{}, err
+	}
+	if !hmac.Equal(tag, sign(payload, d.fingerprint())) || payload[0] != challengeVersion {
+		return Challenge{}, ErrBadChallenge
+	}
+	idx := int(payload[2])<<16 | int(payload[3])<<8 | int(payload[4])
+	if int(payload[1]) != d.length || idx >= len(d.guesses) {
+		return Challenge{}, ErrBadChallenge
+	}
+	return Challenge{
+		Word:       d.guesses[idx],
+		MaxGuesses: int(payload[5]),
+		HardMode:   payload[6]&1 != 0,
+	}, nil
+}
+
+// EncodeResult returns a code for how a player did at the challenge with the
+// given code, to send back to whoever made it.
+func EncodeResult(challenge string, r Result) (string, error) {
+	if r.Guesses < 0 || r.Guesses > 255 {
+		return "", fmt.Errorf("can't make a result with %d guesses", r.Guesses)
+	}
+	var solved byte
+	if r.Solved {
+		solved = 1
+	}
+	return seal([]byte{solved, byte(r.Guesses)}, resultContext(challenge)), nil
+}
+
+// DecodeResult returns the result in a code made by EncodeResult for the same
+// challenge code.
+func DecodeResult(challenge, code string) (Result, error) {
+	payload, tag, err := open(code, 2)
+	if err != nil {
+		return Result{}, err
+	}
+	if !hmac.Equal(tag, sign(payload, resultContext(challenge))) {
+		return Result{}, ErrBadChallenge
+	}
+	return Result{Solved: payload[0] == 1, Guesses: int(payload[1])}, nil
+}
+
+// resultContext ties a result code to its challenge.
+func resultContext(challenge string) []byte {
+	return append([]byte("result:"), normalizeCode(challenge)...)
+}
+
+// fingerprint identifies the dictionary's guesses, so that a code can't be
+// decoded into the wrong word by a different dictionary.
+func (d *Dictionary) fingerprint() []byte {
+	h := fnv.New64a()
+	for _, word := range d.guesses {
+		h.Write([]byte(word))
+	}
+	return h.Sum(nil)
+}
+
+// sign returns the tag for payload in context.
+func sign(payload, context []byte) []byte {
+	mac := hmac.New(sha256.New, challengeKey)
+	mac.Write(context)
+	mac.Write([]byte{0})
+	mac.Write(payload)
+	return mac.Sum(nil)[:tagSize]
+}
+
+// mask returns the bytes payload is XORed with for a tag, so that the same
+// payload reads differently in every context.
+func mask(tag []byte, n int) []byte {
+	sum := sha256.Sum256(append(append([]byte(nil), challengeKey...), tag...))
+	return sum[:n]
+}
+
+// seal signs payload in context and writes it as a code.
+func seal(payload, context []byte) string {
+	tag := sign(payload, context)
+	code := append([]byte(nil), tag...)
+	for i, b := range mask(tag, len(payload)) {
+		code = append(code, payload[i]^b)
+	}
+	return codeEncoding.EncodeToString(code)
+}
+
+// open reads a code with a payload of n bytes, returning the payload and the
+// tag it should have. The caller checks the tag.
+func open(code string, n int) (payload, tag []byte, err error) {
+	b, err := codeEncoding.DecodeString(string(normalizeCode(code)))
+	if err != nil || len(b) != tagSize+n {
+		return nil, nil, ErrBadChallenge
+	}
+	tag = b[:tagSize]
+	payload = b[tagSize:]
+	for i, m := range mask(tag, n) {
+		payload[i] ^= m
+	}
+	return payload, tag, nil
+}
+
+// normalizeCode lets codes be typed in lower case and with dashes or spaces.
+func normalizeCode(code string) []byte {
+	code = strings.ToUpper(code)
+	return bytes.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, []byte(code))
+}
@@ -0,0 +1,65 @@
+package words
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestChallenge(t *testing.T) {
+	d, _ := Builtin(5)
+	want := Challenge{Word: "ZONKS", MaxGuesses: 4, HardMode: true}
+	code, err := d.EncodeChallenge(Challenge{Word: "zonks", MaxGuesses: 4, HardMode: true})
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if strings.Contains(code, "ZONKS") {
+		t.Errorf("Code %s gives the word away", code)
+	}
+
+	if l, err := ChallengeLength(code); err != nil || l != 5 {
+		t.Errorf("ChallengeLength = %d, %v, want 5", l, err)
+	}
+	got, err := d.DecodeChallenge(strings.ToLower(code[:4]) + "-" + code[4:])
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if got != want {
+		t.Errorf("DecodeChallenge = %+v, want %+v", got, want)
+	}
+
+	// a typo shouldn't decode to some other word
+	typo := []byte(code)
+	typo[len(typo)-3] ^= 1
+	if _, err := d.DecodeChallenge(string(typo)); err != ErrBadChallenge {
+		t.Errorf("Typo error = %v, want ErrBadChallenge", err)
+	}
+	other, _ := New([]string{"ZONKS", "CRANE"}, nil)
+	if _, err := other.DecodeChallenge(code); err != ErrBadChallenge {
+		t.Errorf("Other word list error = %v, want ErrBadChallenge", err)
+	}
+
+	if _, err := d.EncodeChallenge(Challenge{Word: "ZZZZZ"}); err == nil {
+		t.Errorf("Should error out for a word that isn't in the list, but didn't")
+	}
+}
+
+func TestResult(t *testing.T) {
+	d, _ := Builtin(5)
+	challenge, _ := d.EncodeChallenge(Challenge{Word: "CRANE", MaxGuesses: 6})
+	other, _ := d.EncodeChallenge(Challenge{Word: "SLATE", MaxGuesses: 6})
+
+	code, err := EncodeResult(challenge, Result{Solved: true, Guesses: 4})
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	got, err := DecodeResult(challenge, code)
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if got != (Result{Solved: true, Guesses: 4}) {
+		t.Errorf("DecodeResult = %+v, want solved in 4", got)
+	}
+	if _, err := DecodeResult(other, code); err != ErrBadChallenge {
+		t.Errorf("Result for another challenge error = %v, want ErrBadChallenge", err)
+	}
+}
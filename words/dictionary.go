@@ -0,0 +1,170 @@
+package words
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrUnsupportedLength is returned when there are no words of a requested
+// length.
+var ErrUnsupportedLength = errors.New("no words of that length")
+
+// Dictionary is the words a game is played with: the answers it can pick,
+// and the guesses it accepts. Every word in a dictionary has the same number
+// of letters, and every answer is also a guess. A nil *Dictionary has no
+// words.
+type Dictionary struct {
+	length  int
+	answers []string
+	// guesses are in alphabetical order.
+	guesses []string
+	valid   map[string]struct{}
+	// suggest finds guesses near a word, for Suggest.
+	suggest suggestIndex
+}
+
+// New returns a dictionary with the given answers and guesses. Words are
+// upper-cased and duplicates are dropped, keeping the order of the answers.
+// It returns an error unless every word has the same number of letters, only
+// uses A to Z, and every answer is also a guess. If guesses is nil, only the
+// answers can be guessed.
+func New(answers, guesses []string) (*Dictionary, error) {
+	if guesses == nil {
+		guesses = answers
+	}
+	answers, err := clean(answers, "answer")
+	if err != nil {
+		return nil, err
+	}
+	if len(answers) == 0 {
+		return nil, errors.New("no answers")
+	}
+	guesses, err = clean(guesses, "guess")
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Dictionary{
+		length:  len(answers[0]),
+		answers: answers,
+		guesses: guesses,
+		valid:   make(map[string]struct{}, len(guesses)),
+	}
+	for _, word := range guesses {
+		if len(word) != d.length {
+			return nil, fmt.Errorf("guess %s has %d letters, want %d", word, len(word), d.length)
+		}
+		d.valid[word] = struct{}{}
+	}
+	for _, word := range answers {
+		if len(word) != d.length {
+			return nil, fmt.Errorf("answer %s has %d letters, want %d", word, len(word), d.length)
+		}
+		if !d.Contains(word) {
+			return nil, fmt.Errorf("answer %s isn't in the guesses", word)
+		}
+	}
+	sort.Strings(d.guesses)
+	return d, nil
+}
+
+// clean upper-cases list and drops duplicates, returning an error for any
+// word with something other than letters in it. kind says what the words are
+// for the error.
+func clean(list []string, kind string) ([]string, error) {
+	seen := make(map[string]bool, len(list))
+	cleaned := make([]string, 0, len(list))
+	for _, word := range list {
+		word = strings.ToUpper(word)
+		if word == "" {
+			return nil, fmt.Errorf("empty %s", kind)
+		}
+		for i := 0; i < len(word); i++ {
+			if word[i] < 'A' || word[i] > 'Z' {
+				return nil, fmt.Errorf("%s %q has something other than A to Z in it", kind, word)
+			}
+		}
+		if !seen[word] {
+			seen[word] = true
+			cleaned = append(cleaned, word)
+		}
+	}
+	return cleaned, nil
+}
+
+// Length returns the number of letters in every word of the dictionary.
+func (d *Dictionary) Length() int {
+	if d == nil {
+		return 0
+	}
+	return d.length
+}
+
+// Contains reports whether word is a valid guess.
+func (d *Dictionary) Contains(word string) bool {
+	if d == nil {
+		return false
+	}
+	_, ok := d.valid[word]
+	return ok
+}
+
+// Answers returns a copy of the words that can be picked as answers.
+func (d *Dictionary) Answers() []string {
+	if d == nil {
+		return nil
+	}
+	return append([]string(nil), d.answers...)
+}
+
+// Guesses returns a copy of every valid guess in alphabetical order.
+func (d *Dictionary) Guesses() []string {
+	if d == nil {
+		return nil
+	}
+	return append([]string(nil), d.guesses...)
+}
+
+// Random picks an answer at random using r. It returns "" if d is nil, since
+// there's nothing to pick.
+func (d *Dictionary) Random(r *rand.Rand) string {
+	if d == nil {
+		return ""
+	}
+	return d.answers[r.Intn(len(d.answers))]
+}
+
+// Epoch is the calendar day of daily puzzle #1.
+var Epoch = time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+// Daily returns the answer of the day for the calendar day of date, along
+// with the puzzle number counted from Epoch. Everyone asking the same
+// dictionary for the same day and salt gets the same word, so a team can pick
+// its own salt to get a puzzle separate from everyone else's. Days before
+// Epoch get puzzle #1, since there weren't any puzzles yet. It returns "" and
+// 0 if d is nil.
+func (d *Dictionary) Daily(date time.Time, salt string) (string, int) {
+	if d == nil {
+		return "", 0
+	}
+	// only the calendar day matters, not the time or location
+	y, m, day := date.Date()
+	midnight := time.Date(y, m, day, 0, 0, 0, 0, time.UTC)
+	if midnight.Before(Epoch) {
+		midnight = Epoch
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(midnight.Format("2006-01-02")))
+	h.Write([]byte{0})
+	h.Write([]byte(salt))
+	idx := h.Sum64() % uint64(len(d.answers))
+
+	puzzle := int(midnight.Sub(Epoch).Hours()/24) + 1
+	return d.answers[idx], puzzle
+}
@@ -0,0 +1,202 @@
+package words
+
+var (
+	// wordsCommon4 is a list of commonly used four letter words.
+	wordsCommon4 = []string{
+		"ABLE", "ACID", "AGED", "ALSO", "AREA", "ARMY", "AWAY", "BABY",
+		"BACK", "BALL", "BAND", "BANK", "BASE", "BATH", "BEAR", "BEAT",
+		"BEEN", "BEER", "BELL", "BELT", "BEST", "BILL", "BIRD", "BLOW",
+		"BLUE", "BOAT", "BODY", "BOMB", "BOND", "BONE", "BOOK", "BOOM",
+		"BORN", "BOSS", "BOTH", "BOWL", "BULK", "BURN", "BUSH", "BUSY",
+		"CAKE", "CALL", "CALM", "CAME", "CAMP", "CARD", "CARE", "CASE",
+		"CASH", "CAST", "CELL", "CHAT", "CHIP", "CITY", "CLUB", "COAL",
+		"COAT", "CODE", "COLD", "COME", "COOK", "COOL", "COPE", "COPY",
+		"CORE", "COST", "CREW", "CROP", "DARK", "DATA", "DATE", "DAWN",
+		"DAYS", "DEAD", "DEAL", "DEAN", "DEAR", "DEBT", "DEEP", "DENY",
+		"DESK", "DIAL", "DIET", "DISK", "DOES", "DONE", "DOOR", "DOSE",
+		"DOWN", "DRAW", "DREW", "DROP", "DRUG", "DUAL", "DUKE", "DUST",
+		"DUTY", "EACH", "EARN", "EASE", "EAST", "EASY", "EDGE", "ELSE",
+		"EVEN", "EVER", "EXIT", "FACE", "FACT", "FAIL", "FAIR", "FALL",
+		"FARM", "FAST", "FATE", "FEAR", "FEED", "FEEL", "FEET", "FELL",
+		"FELT", "FILE", "FILL", "FILM", "FIND", "FINE", "FIRE", "FIRM",
+		"FISH", "FIVE", "FLAT", "FLOW", "FOOD", "FOOT", "FORM", "FORT",
+		"FOUR", "FREE", "FROM", "FUEL", "FULL", "FUND", "GAIN", "GAME",
+		"GATE", "GAVE", "GEAR", "GENE", "GIFT", "GIRL", "GIVE", "GLAD",
+		"GOAL", "GOES", "GOLD", "GOLF", "GONE", "GOOD", "GRAY", "GREW",
+		"GREY", "GROW", "GULF", "HAIR", "HALF", "HALL", "HAND", "HANG",
+		"HARD", "HARM", "HATE", "HAVE", "HEAD", "HEAR", "HEAT", "HELD",
+		"HELL", "HELP", "HERE", "HERO", "HIGH", "HILL", "HIRE", "HOLD",
+		"HOLE", "HOLY", "HOME", "HOPE", "HOST", "HOUR", "HUGE", "HUNG",
+		"HUNT", "HURT", "IDEA", "INCH", "INTO", "IRON", "ITEM", "JAZZ",
+		"JOIN", "JUMP", "JURY", "JUST", "KEEN", "KEEP", "KEPT", "KICK",
+		"KILL", "KIND", "KING", "KNEE", "KNEW", "KNOW", "LACK", "LADY",
+		"LAID", "LAKE", "LAND", "LANE", "LAST", "LATE", "LEAD", "LEFT",
+		"LESS", "LIFE", "LIFT", "LIKE", "LINE", "LINK", "LIST", "LIVE",
+		"LOAD", "LOAN", "LOCK", "LONG", "LOOK", "LORD", "LOSE", "LOSS",
+		"LOST", "LOVE", "LUCK", "MADE", "MAIL", "MAIN", "MAKE", "MALE",
+		"MANY", "MARK", "MASS", "MATE", "MEAL", "MEAN", "MEAT", "MEET",
+		"MENU", "MERE", "MILD", "MILE", "MILK", "MILL", "MIND", "MINE",
+		"MISS", "MODE", "MOOD", "MOON", "MORE", "MOST", "MOVE", "MUCH",
+		"MUST", "NAME", "NAVY", "NEAR", "NECK", "NEED", "NEWS", "NEXT",
+		"NICE", "NINE", "NONE", "NOSE", "NOTE", "OKAY", "ONCE", "ONLY",
+		"OPEN", "ORAL", "OVER", "PACE", "PACK", "PAGE", "PAID", "PAIN",
+		"PAIR", "PALE", "PALM", "PARK", "PART", "PASS", "PAST", "PATH",
+		"PEAK", "PICK", "PINK", "PIPE", "PLAN", "PLAY", "PLOT", "PLUG",
+		"PLUS", "POEM", "POET", "POLE", "POLL", "POOL", "POOR", "PORT",
+		"POST", "POUR", "PRAY", "PULL", "PURE", "PUSH", "QUIT", "RACE",
+		"RAIL", "RAIN", "RANK", "RARE", "RATE", "READ", "REAL", "REAR",
+		"RELY", "RENT", "REST", "RICE", "RICH", "RIDE", "RING", "RISE",
+		"RISK", "ROAD", "ROCK", "ROLE", "ROLL", "ROOF", "ROOM", "ROOT",
+		"ROPE", "ROSE", "RULE", "RUSH", "SAFE", "SAID", "SAKE", "SALE",
+		"SALT", "SAME", "SAND", "SAVE", "SEAT", "SEED", "SEEK", "SEEM",
+		"SEEN", "SELF", "SELL", "SEND", "SENT", "SHIP", "SHOP", "SHOT",
+		"SHOW", "SHUT", "SICK", "SIDE", "SIGN", "SILK", "SING", "SINK",
+		"SITE", "SIZE", "SKIN", "SLIP", "SLOW", "SNOW", "SOFT", "SOIL",
+		"SOLD", "SOLE", "SOME", "SONG", "SOON", "SORT", "SOUL", "SPOT",
+		"STAR", "STAY", "STEP", "STOP", "SUCH", "SUIT", "SURE", "TAIL",
+		"TAKE", "TALE", "TALK", "TALL", "TANK", "TAPE", "TASK", "TEAM",
+		"TEAR", "TELL", "TEND", "TENT", "TERM", "TEST", "TEXT", "THAN",
+		"THAT", "THEM", "THEN", "THEY", "THIN", "THIS", "THUS", "TIDE",
+		"TIED", "TIER", "TILL", "TIME", "TINY", "TOLD", "TOLL", "TONE",
+		"TOOK", "TOOL", "TOUR", "TOWN", "TREE", "TRIP", "TRUE", "TUNE",
+		"TURN", "TWIN", "TYPE", "UNIT", "UPON", "USED", "USER", "VARY",
+		"VAST", "VERY", "VIEW", "VOTE", "WAGE", "WAIT", "WAKE", "WALK",
+		"WALL", "WANT", "WARM", "WARN", "WASH", "WAVE", "WEAK", "WEAR",
+		"WEEK", "WELL", "WENT", "WERE", "WEST", "WHAT", "WHEN", "WHOM",
+		"WIDE", "WIFE", "WILD", "WILL", "WIND", "WINE", "WING", "WIRE",
+		"WISE", "WISH", "WITH", "WOOD", "WORD", "WORE", "WORK", "YARD",
+		"YEAH", "YEAR", "YOUR", "ZERO", "ZONE",
+	}
+
+	// wordsCommon6 is a list of commonly used six letter words.
+	wordsCommon6 = []string{
+		"ACCEPT", "ACCESS", "ACROSS", "ACTING", "ACTION", "ACTIVE", "ACTUAL", "ADVICE",
+		"ADVISE", "AFFECT", "AFFORD", "AFRAID", "AGENCY", "AGENDA", "ALMOST", "ALWAYS",
+		"AMOUNT", "ANIMAL", "ANNUAL", "ANSWER", "ANYONE", "ANYWAY", "APPEAL", "APPEAR",
+		"AROUND", "ARRIVE", "ARTIST", "ASPECT", "ASSESS", "ASSIST", "ASSUME", "ATTACK",
+		"ATTEND", "AUTUMN", "AVENUE", "BACKED", "BANNER", "BARELY", "BATTLE", "BEAUTY",
+		"BECAME", "BECOME", "BEFORE", "BEHALF", "BEHIND", "BELIEF", "BELONG", "BESIDE",
+		"BETTER", "BEYOND", "BISHOP", "BORDER", "BOTTLE", "BOTTOM", "BOUGHT", "BRANCH",
+		"BREATH", "BRIDGE", "BRIGHT", "BROKEN", "BUDGET", "BURDEN", "BUREAU", "BUTTON",
+		"CAMERA", "CANCER", "CANNOT", "CARBON", "CAREER", "CASTLE", "CASUAL", "CAUGHT",
+		"CENTER", "CENTRE", "CHANCE", "CHANGE", "CHARGE", "CHOICE", "CHOOSE", "CHOSEN",
+		"CHURCH", "CIRCLE", "CLIENT", "CLOSED", "CLOSER", "COFFEE", "COLUMN", "COMBAT",
+		"COMING", "COMMON", "COPPER", "CORNER", "COSTLY", "COTTON", "COUNTY", "COUPLE",
+		"COURSE", "COVERS", "CREATE", "CREDIT", "CRISIS", "CUSTOM", "DAMAGE", "DANGER",
+		"DEALER", "DEBATE", "DECADE", "DECIDE", "DEFEAT", "DEFEND", "DEFINE", "DEGREE",
+		"DEMAND", "DEPEND", "DEPUTY", "DESERT", "DESIGN", "DESIRE", "DETAIL", "DETECT",
+		"DEVICE", "DIFFER", "DINNER", "DIRECT", "DOCTOR", "DOLLAR", "DOMAIN", "DOUBLE",
+		"DRIVEN", "DRIVER", "DURING", "EASILY", "EATING", "EDITOR", "EFFECT", "EFFORT",
+		"EIGHTH", "EITHER", "ELEVEN", "EMERGE", "EMPIRE", "EMPLOY", "ENDING", "ENERGY",
+		"ENGAGE", "ENGINE", "ENOUGH", "ENSURE", "ENTIRE", "ENTITY", "EQUITY", "ESCAPE",
+		"ESTATE", "ETHNIC", "EXCEED", "EXCEPT", "EXCESS", "EXPAND", "EXPECT", "EXPERT",
+		"EXPORT", "EXTEND", "EXTENT", "FABRIC", "FACING", "FACTOR", "FAILED", "FAIRLY",
+		"FALLEN", "FAMILY", "FAMOUS", "FATHER", "FELLOW", "FEMALE", "FIGURE", "FILING",
+		"FINGER", "FINISH", "FISCAL", "FLIGHT", "FLYING", "FOLLOW", "FORCED", "FOREST",
+		"FORGET", "FORMAL", "FORMAT", "FORMER", "FOSTER", "FOUGHT", "FOURTH", "FRIEND",
+		"FUTURE", "GARDEN", "GATHER", "GENDER", "GENTLE", "GLOBAL", "GOLDEN", "GROUND",
+		"GROWTH", "GUILTY", "HANDED", "HANDLE", "HAPPEN", "HARDLY", "HEADED", "HEALTH",
+		"HEIGHT", "HIDDEN", "HOLDER", "HONEST", "IMPACT", "IMPORT", "INCOME", "INDEED",
+		"INJURY", "INSIDE", "INTEND", "INTENT", "INVEST", "ISLAND", "ITSELF", "JERSEY",
+		"JUNIOR", "KILLED", "LABOUR", "LATEST", "LATTER", "LAUNCH", "LAWYER", "LEADER",
+		"LEAGUE", "LEAVES", "LEGACY", "LENGTH", "LESSON", "LETTER", "LIGHTS", "LIKELY",
+		"LINKED", "LIQUID", "LISTEN", "LITTLE", "LIVING", "LOSING", "LOVELY", "LUXURY",
+		"MAINLY", "MAKING", "MANAGE", "MANNER", "MANUAL", "MARGIN", "MARINE", "MARKED",
+		"MARKET", "MASTER", "MATTER", "MATURE", "MEDIUM", "MEMBER", "MEMORY", "MENTAL",
+		"MERELY", "MERGER", "METHOD", "MIDDLE", "MINING", "MINUTE", "MIRROR",
+		"MOBILE", "MODERN", "MODEST", "MODULE", "MOMENT", "MORALE", "MOSTLY", "MOTHER",
+		"MOTION", "MOTIVE", "MURDER", "MUSCLE", "MUSEUM", "MUTUAL", "MYSELF", "NARROW",
+		"NATION", "NATIVE", "NATURE", "NEARBY", "NEARLY", "NIGHTS", "NOBODY", "NORMAL",
+		"NOTICE", "NOTION", "NUMBER", "OBJECT", "OBTAIN", "OFFICE", "OFFSET", "ONLINE",
+		"OPTION", "ORANGE", "ORIGIN", "OUTPUT", "PACKED", "PALACE", "PARENT",
+		"PARTLY", "PATENT", "PEOPLE", "PERIOD", "PERMIT", "PERSON", "PHRASE", "PICKED",
+		"PLANET", "PLAYER", "PLEASE", "PLENTY", "POCKET", "POLICE", "POLICY", "PREFER",
+		"PRETTY", "PRINCE", "PRISON", "PROFIT", "PROPER", "PROVEN", "PUBLIC", "PURSUE",
+		"RAISED", "RANDOM", "RARELY", "RATHER", "RATING", "READER", "REALLY", "REASON",
+		"RECALL", "RECENT", "RECORD", "REDUCE", "REFORM", "REGARD", "REGIME", "REGION",
+		"RELATE", "RELIEF", "REMAIN", "REMOTE", "REMOVE", "REPAIR", "REPEAT", "REPLAY",
+		"REPORT", "RESCUE", "RESORT", "RESULT", "RETAIL", "RETAIN", "RETURN", "REVEAL",
+		"REVIEW", "REWARD", "RIDING", "RISING", "ROBUST", "RULING", "SAFELY", "SAFETY",
+		"SALARY", "SAMPLE", "SAVING", "SAYING", "SCHEME", "SCHOOL", "SCREEN", "SEARCH",
+		"SEASON", "SECOND", "SECRET", "SECTOR", "SECURE", "SEEING", "SELECT", "SELLER",
+		"SENIOR", "SERIES", "SERVER", "SETTLE", "SEVERE", "SHOULD", "SIGNAL", "SIGNED",
+		"SILENT", "SILVER", "SIMPLE", "SIMPLY", "SINGLE", "SISTER", "SLIGHT", "SMOOTH",
+		"SOCIAL", "SOLELY", "SOUGHT", "SOURCE", "SPEECH", "SPIRIT", "SPOKEN", "SPREAD",
+		"SPRING", "SQUARE", "STABLE", "STATUS", "STEADY", "STOLEN", "STRAIN", "STREAM",
+		"STREET", "STRESS", "STRICT", "STRIKE", "STRING", "STRONG", "STRUCK", "STUDIO",
+		"SUBMIT", "SUDDEN", "SUFFER", "SUMMER", "SUMMIT", "SUPPLY", "SURELY", "SURVEY",
+		"SWITCH", "SYMBOL", "SYSTEM", "TAKING", "TALENT", "TARGET", "TAUGHT", "TENANT",
+		"TENDER", "TENNIS", "THANKS", "THEORY", "THIRTY", "THOUGH", "THREAT", "THROWN",
+		"TICKET", "TIMELY", "TIMING", "TISSUE", "TOWARD", "TRAVEL", "TREATY", "TRYING",
+		"TWELVE", "TWENTY", "UNIQUE", "UNLESS", "UNLIKE", "UPDATE", "USEFUL", "VALLEY",
+		"VARIED", "VENDOR", "VERSUS", "VICTIM", "VISION", "VISUAL", "VOLUME", "WALKER",
+		"WEALTH", "WEEKLY", "WEIGHT", "WHOLLY", "WINDOW", "WINNER", "WINTER", "WITHIN",
+		"WONDER", "WORKER", "WRITER", "YELLOW",
+	}
+
+	// wordsCommon7 is a list of commonly used seven letter words.
+	wordsCommon7 = []string{
+		"ABILITY", "ABSENCE", "ACADEMY", "ACCOUNT", "ACCUSED", "ACHIEVE", "ACQUIRE", "ADDRESS",
+		"ADVANCE", "ADVERSE", "ADVISED", "ADVISER", "AGAINST", "AIRLINE", "AIRPORT", "ALCOHOL",
+		"ALLEGED", "ALREADY", "ANALYST", "ANCIENT", "ANOTHER", "ANXIETY", "ANXIOUS", "ANYBODY",
+		"APPLIED", "ARRANGE", "ARRIVAL", "ARTICLE", "ASSAULT", "ASSUMED", "ASSURED", "ATTEMPT",
+		"ATTRACT", "AUCTION", "AVERAGE", "BACKING", "BALANCE", "BANKING", "BARRIER", "BATTERY",
+		"BEARING", "BEATING", "BECAUSE", "BEDROOM", "BELIEVE", "BENEATH", "BENEFIT", "BESIDES",
+		"BETWEEN", "BILLION", "BINDING", "BROTHER", "BROUGHT", "BURNING", "CABINET", "CALIBER",
+		"CALLING", "CAPABLE", "CAPITAL", "CAPTAIN", "CAPTION", "CAPTURE", "CAREFUL", "CARRIER",
+		"CAUTION", "CEILING", "CENTRAL", "CENTURY", "CERTAIN", "CHAMBER", "CHANNEL", "CHAPTER",
+		"CHARITY", "CHARTER", "CHECKED", "CHICKEN", "CHRONIC", "CIRCUIT", "CLASSIC", "CLIMATE",
+		"CLOSING", "CLOTHES", "COLLECT", "COLLEGE", "COMBINE", "COMFORT", "COMMAND", "COMMENT",
+		"COMPACT", "COMPANY", "COMPARE", "COMPETE", "COMPLEX", "CONCEPT", "CONCERN", "CONCERT",
+		"CONDUCT", "CONFIRM", "CONNECT", "CONSENT", "CONSIST", "CONTACT", "CONTAIN", "CONTENT",
+		"CONTEST", "CONTEXT", "CONTROL", "CONVERT", "CORRECT", "COUNCIL", "COUNSEL", "COUNTER",
+		"COUNTRY", "CRUCIAL", "CRYSTAL", "CULTURE", "CURRENT", "CUTTING", "DEALING", "DECIDED",
+		"DECLINE", "DEFAULT", "DEFENCE", "DEFICIT", "DELIVER", "DENSITY", "DEPOSIT", "DESKTOP",
+		"DESPITE", "DESTROY", "DEVELOP", "DEVOTED", "DIAMOND", "DIGITAL", "DISCUSS", "DISEASE",
+		"DISPLAY", "DISPUTE", "DISTANT", "DIVERSE", "DIVIDED", "DRAWING", "DRIVING", "DYNAMIC",
+		"EASTERN", "ECONOMY", "EDITION", "ELDERLY", "ELEMENT", "ENGAGED", "ENHANCE", "ESSENCE",
+		"EVENING", "EVIDENT", "EXACTLY", "EXAMINE", "EXAMPLE", "EXCITED", "EXCLUDE", "EXHIBIT",
+		"EXPENSE", "EXPLAIN", "EXPLORE", "EXPRESS", "EXTREME", "FACTORY", "FACULTY", "FAILING",
+		"FAILURE", "FASHION", "FEATURE", "FEDERAL", "FEELING", "FICTION", "FIFTEEN", "FILLING",
+		"FINANCE", "FINDING", "FISHING", "FITNESS", "FOREIGN", "FOREVER", "FORMULA", "FORTUNE",
+		"FORWARD", "FOUNDER", "FREEDOM", "FURTHER", "GALLERY", "GATEWAY", "GENERAL", "GENETIC",
+		"GENUINE", "GIGABIT", "GREATER", "HANGING", "HEADING", "HEALTHY", "HEARING", "HEAVILY",
+		"HELPFUL", "HELPING", "HERSELF", "HIGHWAY", "HIMSELF", "HISTORY", "HOLDING", "HOLIDAY",
+		"HOUSING", "HOWEVER", "HUNDRED", "HUSBAND", "ILLEGAL", "ILLNESS", "IMAGINE", "IMAGING",
+		"IMPROVE", "INCLUDE", "INITIAL", "INQUIRY", "INSIGHT", "INSTALL", "INSTANT", "INSTEAD",
+		"INTENSE", "INTERIM", "INVOLVE", "JOURNAL", "JOURNEY", "JUSTICE", "JUSTIFY", "KEEPING",
+		"KILLING", "KINGDOM", "KITCHEN", "KNOWING", "LANDING", "LARGELY", "LASTING", "LEADING",
+		"LEARNED", "LEISURE", "LIBERAL", "LIBERTY", "LIBRARY", "LICENSE", "LIMITED", "LISTING",
+		"LOGICAL", "LOYALTY", "MACHINE", "MANAGER", "MARRIED", "MASSIVE", "MAXIMUM", "MEANING",
+		"MEASURE", "MEDICAL", "MEETING", "MENTION", "MESSAGE", "MILLION", "MINERAL", "MINIMAL",
+		"MINIMUM", "MISSING", "MISSION", "MISTAKE", "MIXTURE", "MONITOR", "MONTHLY", "MORNING",
+		"MYSTERY", "NATURAL", "NEITHER", "NERVOUS", "NETWORK", "NEUTRAL", "NOTABLE", "NOTHING",
+		"NOWHERE", "NUCLEAR", "NURSING", "OBVIOUS", "OFFENCE", "OFFICER", "ONGOING", "OPENING",
+		"OPERATE", "OPINION", "OPTICAL", "ORGANIC", "OUTCOME", "OUTDOOR", "OUTLOOK", "OUTSIDE",
+		"OVERALL", "PACKAGE", "PAINTED", "PARKING", "PARTIAL", "PARTNER", "PASSAGE", "PASSING",
+		"PASSION", "PASSIVE", "PATIENT", "PATTERN", "PAYABLE", "PAYMENT", "PENALTY", "PENDING",
+		"PENSION", "PERCENT", "PERFECT", "PERFORM", "PERHAPS", "PHYSICS", "PICTURE", "PIONEER",
+		"PLASTIC", "POINTED", "POPULAR", "PORTION", "POVERTY", "PRECISE", "PREDICT", "PREMIER",
+		"PREMIUM", "PREPARE", "PRESENT", "PREVENT", "PRIMARY", "PRINTER", "PRIVACY", "PRIVATE",
+		"PROBLEM", "PROCEED", "PROCESS", "PRODUCE", "PRODUCT", "PROFILE", "PROGRAM", "PROJECT",
+		"PROMISE", "PROMOTE", "PROTECT", "PROTEIN", "PROTEST", "PROVIDE", "PUBLISH", "PURPOSE",
+		"PUSHING", "QUALIFY", "QUARTER", "RADICAL", "RAILWAY", "READILY", "READING", "REALITY",
+		"REALIZE", "RECEIPT", "RECEIVE", "RECOVER", "REFLECT", "REGULAR", "RELATED", "RELEASE",
+		"REMAINS", "REMOVAL", "REMOVED", "REPLACE", "REQUEST", "REQUIRE", "RESERVE", "RESOLVE",
+		"RESPECT", "RESPOND", "RESTORE", "RETIRED", "REVENUE", "REVERSE", "ROUTINE", "RUNNING",
+		"SATISFY", "SCIENCE", "SECTION", "SEGMENT", "SERIOUS", "SERVICE", "SERVING", "SESSION",
+		"SETTING", "SEVERAL", "SHORTLY", "SHOWING", "SILENCE", "SIMILAR", "SITTING", "SOCIETY",
+		"SOMEHOW", "SOMEONE", "SPEAKER", "SPECIAL", "SPECIES", "SPONSOR", "STATION", "STORAGE",
+		"STRANGE", "STRETCH", "STUDENT", "STUDIED", "SUBJECT", "SUCCEED", "SUCCESS", "SUGGEST",
+		"SUMMARY", "SUPPORT", "SUPPOSE", "SUPREME", "SURFACE", "SURGERY", "SURPLUS", "SURVIVE",
+		"SUSPECT", "SUSTAIN", "TEACHER", "TELLING", "TENSION", "THEATRE", "THERAPY", "THEREBY",
+		"THOUGHT", "THROUGH", "TONIGHT", "TOTALLY", "TOUCHED", "TOWARDS", "TRADING", "TRAFFIC",
+		"TRAINED", "TRAINER", "TRANSIT", "TREATED", "TRIBUNE", "TROUBLE", "TYPICAL",
+		"UNIFORM", "UNKNOWN", "UNUSUAL", "UPGRADE", "USUALLY", "UTILITY", "VARIETY",
+		"VARIOUS", "VEHICLE", "VENTURE", "VERSION", "VETERAN", "VICTORY", "VIEWING", "VILLAGE",
+		"VIOLENT", "VIRTUAL", "VISIBLE", "WAITING", "WALKING", "WANTING", "WARNING", "WARRANT",
+		"WEALTHY", "WEATHER", "WEBSITE", "WEDDING", "WEEKEND", "WELCOME", "WELFARE", "WESTERN",
+		"WHEREAS", "WHETHER", "WILLING", "WINNING", "WITHOUT", "WITNESS", "WORKING", "WRITING",
+		"WRITTEN",
+	}
+)
@@ -0,0 +1,88 @@
+package words
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Load returns a dictionary with the answers in answersPath and the guesses in
+// guessesPath. If guessesPath is empty, the answers can be guessed along with
+// the built-in guesses of the same length. See ReadList for the formats the
+// files can be in.
+func Load(answersPath, guessesPath string) (*Dictionary, error) {
+	answers, err := ReadFile(answersPath)
+	if err != nil {
+		return nil, err
+	}
+	var guesses []string
+	if guessesPath != "" {
+		guesses, err = ReadFile(guessesPath)
+		if err != nil {
+			return nil, err
+		}
+	} else if len(answers) > 0 {
+		guesses = append(guesses, answers...)
+		if d, err := Builtin(len(answers[0])); err == nil {
+			guesses = append(guesses, d.guesses...)
+		}
+	}
+
+	d, err := New(answers, guesses)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", answersPath, err)
+	}
+	return d, nil
+}
+
+// ReadFile reads a list of words from path. Its format is picked from its
+// name, as described in ReadList.
+func ReadFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	list, err := ReadList(f, filepath.Base(path))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return list, nil
+}
+
+// ReadList reads a list of words from r, with its format picked from name.
+// A name ending in .gz is gzipped, and the format is picked from the rest of
+// it. A .json list is an array of strings. Anything else is text, with words
+// separated by spaces or new lines and comments starting with #.
+func ReadList(r io.Reader, name string) ([]string, error) {
+	name = strings.ToLower(name)
+	if strings.HasSuffix(name, ".gz") {
+		zr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		return ReadList(zr, strings.TrimSuffix(name, ".gz"))
+	}
+
+	if strings.HasSuffix(name, ".json") {
+		var list []string
+		if err := json.NewDecoder(r).Decode(&list); err != nil {
+			return nil, err
+		}
+		return list, nil
+	}
+
+	var list []string
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line, _, _ := strings.Cut(sc.Text(), "#")
+		list = append(list, strings.Fields(line)...)
+	}
+	return list, sc.Err()
+}
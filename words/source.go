@@ -0,0 +1,144 @@
+package words
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Puzzle identifies the daily puzzle a word was picked for.
+type Puzzle struct {
+	Number int
+	// Date is the calendar day of the puzzle, formatted as YYYY-MM-DD.
+	Date string
+	Salt string
+}
+
+// Pick is a word picked by a WordSource.
+type Pick struct {
+	Word string
+	// Daily is set when the word is a daily puzzle.
+	Daily *Puzzle
+}
+
+// WordSource picks the answers for a series of games. It's safe to call Next
+// from more than one goroutine.
+type WordSource interface {
+	// Next picks the answer for the next game.
+	Next() Pick
+}
+
+// RandomSource picks answers from a dictionary at random, so the same word
+// can come up again straight away.
+type RandomSource struct {
+	dict *Dictionary
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// NewRandom returns a source that picks answers from dict using r.
+func NewRandom(dict *Dictionary, r *rand.Rand) *RandomSource {
+	return &RandomSource{dict: dict, rand: r}
+}
+
+// NewSeeded returns a source that picks answers from dict, and picks the same
+// ones in the same order for the same seed.
+func NewSeeded(dict *Dictionary, seed int64) *RandomSource {
+	return NewRandom(dict, rand.New(rand.NewSource(seed)))
+}
+
+func (s *RandomSource) Next() Pick {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return Pick{Word: s.dict.Random(s.rand)}
+}
+
+// DailySource picks the daily puzzle for whatever day it is.
+type DailySource struct {
+	dict *Dictionary
+	salt string
+	now  func() time.Time
+}
+
+// NewDaily returns a source that picks the daily puzzle from dict for salt,
+// for the day now says it is. If now is nil, it's time.Now.
+func NewDaily(dict *Dictionary, salt string, now func() time.Time) *DailySource {
+	if now == nil {
+		now = time.Now
+	}
+	return &DailySource{dict: dict, salt: salt, now: now}
+}
+
+func (s *DailySource) Next() Pick {
+	today := s.now()
+	word, number := s.dict.Daily(today, s.salt)
+	return Pick{
+		Word: word,
+		Daily: &Puzzle{
+			Number: number,
+			Date:   today.Format("2006-01-02"),
+			Salt:   s.salt,
+		},
+	}
+}
+
+// Fixed is a source that always picks the same word, which is handy in tests.
+type Fixed string
+
+func (f Fixed) Next() Pick {
+	return Pick{Word: string(f)}
+}
+
+// SequentialSource picks answers from a list in order, starting over once it
+// runs out, so that everyone in a tournament plays the same games.
+type SequentialSource struct {
+	mu    sync.Mutex
+	words []string
+	next  int
+}
+
+// NewSequential returns a source that picks list's words in order. It panics
+// if list is empty.
+func NewSequential(list []string) *SequentialSource {
+	if len(list) == 0 {
+		panic("words: NewSequential with no words")
+	}
+	return &SequentialSource{words: append([]string(nil), list...)}
+}
+
+func (s *SequentialSource) Next() Pick {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	word := s.words[s.next]
+	s.next = (s.next + 1) % len(s.words)
+	return Pick{Word: word}
+}
+
+// ShuffleBag picks every answer in a dictionary once, in a random order,
+// before picking any of them again.
+type ShuffleBag struct {
+	dict *Dictionary
+	mu   sync.Mutex
+	rand *rand.Rand
+	bag  []string
+}
+
+// NewShuffleBag returns a source that picks the answers of dict without
+// repeats using r.
+func NewShuffleBag(dict *Dictionary, r *rand.Rand) *ShuffleBag {
+	return &ShuffleBag{dict: dict, rand: r}
+}
+
+func (s *ShuffleBag) Next() Pick {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.bag) == 0 {
+		s.bag = s.dict.Answers()
+		s.rand.Shuffle(len(s.bag), func(i, j int) {
+			s.bag[i], s.bag[j] = s.bag[j], s.bag[i]
+		})
+	}
+	word := s.bag[len(s.bag)-1]
+	s.bag = s.bag[:len(s.bag)-1]
+	return Pick{Word: word}
+}
@@ -0,0 +1,62 @@
+package words
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestSeeded(t *testing.T) {
+	d, _ := Builtin(5)
+	a, b := NewSeeded(d, 42), NewSeeded(d, 42)
+	for i := 0; i < 10; i++ {
+		if wa, wb := a.Next().Word, b.Next().Word; wa != wb {
+			t.Fatalf("Pick %d: %s and %s from the same seed", i, wa, wb)
+		}
+	}
+}
+
+func TestDailySource(t *testing.T) {
+	d, _ := Builtin(5)
+	day := time.Date(2023, time.June, 10, 8, 0, 0, 0, time.UTC)
+	src := NewDaily(d, "team", func() time.Time { return day })
+
+	p := src.Next()
+	want, _ := d.Daily(day, "team")
+	if p.Word != want {
+		t.Errorf("Word = %s, want %s", p.Word, want)
+	}
+	if p.Daily == nil || *p.Daily != (Puzzle{Number: 10, Date: "2023-06-10", Salt: "team"}) {
+		t.Errorf("Daily = %+v, want puzzle #10 on 2023-06-10", p.Daily)
+	}
+}
+
+func TestFixedAndSequential(t *testing.T) {
+	if p := Fixed("CRANE").Next(); p.Word != "CRANE" || p.Daily != nil {
+		t.Errorf("Fixed picked %+v", p)
+	}
+
+	src := NewSequential([]string{"CRANE", "SLATE"})
+	for i, want := range []string{"CRANE", "SLATE", "CRANE"} {
+		if got := src.Next().Word; got != want {
+			t.Errorf("Pick %d = %s, want %s", i, got, want)
+		}
+	}
+}
+
+func TestShuffleBag(t *testing.T) {
+	d, _ := New([]string{"CRANE", "SLATE", "TRACE", "AUDIO"}, nil)
+	src := NewShuffleBag(d, rand.New(rand.NewSource(1)))
+
+	// every word once per round
+	for round := 0; round < 3; round++ {
+		seen := make(map[string]bool)
+		for i := 0; i < 4; i++ {
+			word := src.Next().Word
+			if seen[word] {
+				t.Fatalf("Round %d: %s picked twice", round, word)
+			}
+			seen[word] = true
+		}
+	}
+}
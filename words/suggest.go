@@ -0,0 +1,206 @@
+package words
+
+import (
+	"sort"
+	"sync"
+)
+
+// MaxSuggestDistance is the most edits a suggestion can be from the word it's
+// for.
+const MaxSuggestDistance = 2
+
+// suggestIndex finds the guesses near a word. It's built by PrepareSuggest,
+// or the first time a dictionary is asked for suggestions.
+type suggestIndex struct {
+	once sync.Once
+	// deletes maps every way of deleting up to MaxSuggestDistance letters
+	// from a guess to the guesses it came from. Two words are within that
+	// many edits of each other only if they share one of them, so a lookup
+	// only has to check the guesses filed under the word's own deletions.
+	deletes map[string][]string
+	answers map[string]bool
+}
+
+// deletions adds word and every way of deleting up to n letters from it to
+// found.
+func deletions(word string, n int, found map[string]bool) {
+	if found[word] {
+		return
+	}
+	found[word] = true
+	if n == 0 {
+		return
+	}
+	for i := 0; i < len(word); i++ {
+		deletions(word[:i]+word[i+1:], n-1, found)
+	}
+}
+
+// PrepareSuggest builds what Suggest needs to look words up, if it hasn't
+// been built already. It can take a fraction of a second, so it's worth
+// calling ahead of time somewhere it won't hold anything up. It's safe to call
+// from several goroutines.
+func (d *Dictionary) PrepareSuggest() {
+	if d == nil {
+		return
+	}
+	idx := &d.suggest
+	idx.once.Do(func() {
+		idx.deletes = make(map[string][]string)
+		for _, guess := range d.guesses {
+			found := make(map[string]bool)
+			deletions(guess, MaxSuggestDistance, found)
+			for del := range found {
+				idx.deletes[del] = append(idx.deletes[del], guess)
+			}
+		}
+		idx.answers = make(map[string]bool, len(d.answers))
+		for _, answer := range d.answers {
+			idx.answers[answer] = true
+		}
+	})
+}
+
+// Suggest returns up to n valid guesses that word might have been a typo for,
+// closest first. Guesses are close if few letters have to be added, removed
+// or changed to get them, and closer still if the changed letters are next
+// to each other on a QWERTY keyboard. Answers come before other guesses that
+// are just as close, since they're the more common words. It returns nil if
+// nothing is within MaxSuggestDistance.
+func (d *Dictionary) Suggest(word string, n int) []string {
+	if d == nil || n <= 0 {
+		return nil
+	}
+	d.PrepareSuggest()
+	idx := &d.suggest
+
+	type match struct {
+		word     string
+		distance int
+		typos    int
+		answer   bool
+	}
+	var matches []match
+	checked := map[string]bool{word: true}
+	found := make(map[string]bool)
+	deletions(word, MaxSuggestDistance, found)
+	for del := range found {
+		for _, guess := range idx.deletes[del] {
+			if checked[guess] {
+				continue
+			}
+			checked[guess] = true
+			if distance := editDistance(word, guess); distance <= MaxSuggestDistance {
+				matches = append(matches, match{guess, distance, farTypos(word, guess), idx.answers[guess]})
+			}
+		}
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.distance != b.distance {
+			return a.distance < b.distance
+		}
+		if a.typos != b.typos {
+			return a.typos < b.typos
+		}
+		if a.answer != b.answer {
+			return a.answer
+		}
+		return a.word < b.word
+	})
+	if len(matches) > n {
+		matches = matches[:n]
+	}
+	suggestions := make([]string, len(matches))
+	for i, m := range matches {
+		suggestions[i] = m.word
+	}
+	return suggestions
+}
+
+// editDistance returns the Levenshtein distance between a and b: how many
+// letters have to be added, removed or changed to turn one into the other.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// qwerty is the rows of a QWERTY keyboard. Each row is shifted half a key to
+// the right of the one above it.
+var qwerty = [...]string{"QWERTYUIOP", "ASDFGHJKL", "ZXCVBNM"}
+
+// keyPositions has the row and column of every letter on a QWERTY keyboard.
+var keyPositions = func() map[byte][2]int {
+	pos := make(map[byte][2]int)
+	for r, row := range qwerty {
+		for c := 0; c < len(row); c++ {
+			pos[row[c]] = [2]int{r, c}
+		}
+	}
+	return pos
+}()
+
+// adjacentKeys reports whether a and b are next to each other on a QWERTY
+// keyboard.
+func adjacentKeys(a, b byte) bool {
+	pa, ok := keyPositions[a]
+	pb, ok2 := keyPositions[b]
+	if !ok || !ok2 {
+		return false
+	}
+	switch pb[0] - pa[0] {
+	case 0:
+		return pb[1] == pa[1]-1 || pb[1] == pa[1]+1
+	case -1:
+		// the row above is shifted half a key left
+		return pb[1] == pa[1] || pb[1] == pa[1]+1
+	case 1:
+		return pb[1] == pa[1] || pb[1] == pa[1]-1
+	}
+	return false
+}
+
+// farTypos counts the letters that differ between two words of the same
+// length without being next to each other on the keyboard, which are less
+// likely to be slips of the finger. Words of different lengths count every
+// letter.
+func farTypos(a, b string) int {
+	if len(a) != len(b) {
+		return len(a) + len(b)
+	}
+	n := 0
+	for i := 0; i < len(a); i++ {
+		if a[i] != b[i] && !adjacentKeys(a[i], b[i]) {
+			n++
+		}
+	}
+	return n
+}
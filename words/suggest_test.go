@@ -0,0 +1,58 @@
+package words
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSuggest(t *testing.T) {
+	d, err := New([]string{"CRANE", "CRATE"}, []string{"CRANE", "CRATE", "CRAVE", "GRAVE", "SLATE", "CRANK"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		word string
+		want []string
+	}{
+		// CRANE is one edit away, and the rest are two, with answers first
+		{"CEANE", []string{"CRANE", "CRATE", "CRANK"}},
+		// B is next to N and V, but not T
+		{"CRABE", []string{"CRANE", "CRAVE", "CRATE"}},
+		{"CRNE", []string{"CRANE", "CRATE", "CRANK"}},
+		{"QQQQQ", nil},
+	}
+	for _, test := range tests {
+		got := d.Suggest(test.word, 3)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Suggest(%s) = %v, want %v", test.word, got, test.want)
+		}
+	}
+	if got := d.Suggest("CRANE", 3); len(got) == 0 || got[0] == "CRANE" {
+		t.Errorf("Suggest(CRANE) = %v, want other words", got)
+	}
+	if got := d.Suggest("CRAXE", 1); len(got) != 1 {
+		t.Errorf("Suggest(CRAXE, 1) = %v, want one word", got)
+	}
+}
+
+func TestAdjacentKeys(t *testing.T) {
+	for _, pair := range []string{"QW", "QA", "WA", "AZ", "SZ", "GB", "PL", "MK", "MJ"} {
+		if !adjacentKeys(pair[0], pair[1]) || !adjacentKeys(pair[1], pair[0]) {
+			t.Errorf("%c and %c should be adjacent", pair[0], pair[1])
+		}
+	}
+	for _, pair := range []string{"QS", "QZ", "AX", "PM", "ML", "EE"} {
+		if adjacentKeys(pair[0], pair[1]) {
+			t.Errorf("%c and %c shouldn't be adjacent", pair[0], pair[1])
+		}
+	}
+}
+
+func BenchmarkSuggest(b *testing.B) {
+	d, _ := Builtin(5)
+	d.Suggest("CRANX", 3)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d.Suggest("CRANX", 3)
+	}
+}
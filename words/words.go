@@ -1,32 +1,64 @@
 package words
 
 import (
-	"math/rand"
-	"time"
+	"sort"
+	"sync"
 )
 
-func init() {
-	rand.Seed(time.Now().UnixNano())
+var (
+	builtinOnce sync.Once
+	// builtin maps a word length to its built-in dictionary.
+	builtin map[int]*Dictionary
+)
 
-	wordsSet = make(map[string]struct{}, len(wordsCommon)+len(wordsRare))
-	for _, word := range wordsCommon {
-		wordsSet[word] = struct{}{}
-	}
-	for _, word := range wordsRare {
-		wordsSet[word] = struct{}{}
+// Builtin returns the dictionary that comes with godle for words with the
+// given number of letters. Its answers are common words and its guesses add
+// rarer ones.
+func Builtin(length int) (*Dictionary, error) {
+	builtinOnce.Do(loadBuiltin)
+	d, ok := builtin[length]
+	if !ok {
+		return nil, ErrUnsupportedLength
 	}
+	return d, nil
 }
 
-// GetWord retrieves a random common word.
-func GetWord() string {
-	idx := rand.Intn(len(wordsCommon))
-	return wordsCommon[idx]
+// Lengths returns the word lengths that have a built-in dictionary, in
+// ascending order.
+func Lengths() []int {
+	builtinOnce.Do(loadBuiltin)
+	lengths := make([]int, 0, len(builtin))
+	for l := range builtin {
+		lengths = append(lengths, l)
+	}
+	sort.Ints(lengths)
+	return lengths
 }
 
-// IsWord validates a word.
-func IsWord(word string) bool {
-	_, ok := wordsSet[word]
-	return ok
+func loadBuiltin() {
+	common := map[int][]string{
+		4: wordsCommon4,
+		5: wordsCommon,
+		6: wordsCommon6,
+		7: wordsCommon7,
+	}
+	guesses := make(map[int][]string)
+	for l, list := range common {
+		guesses[l] = append(guesses[l], list...)
+	}
+	for _, word := range wordsRare {
+		guesses[len(word)] = append(guesses[len(word)], word)
+	}
+
+	builtin = make(map[int]*Dictionary, len(common))
+	for l, answers := range common {
+		d, err := New(answers, guesses[l])
+		if err != nil {
+			// the lists are checked by the tests
+			panic("words: bad built-in list: " + err.Error())
+		}
+		builtin[l] = d
+	}
 }
 
 var (
@@ -1660,8 +1692,4 @@ var (
 		"ZUPAN", "ZUPAS", "ZUPPA", "ZURFS", "ZUZIM", "ZYGAL", "ZYGON", "ZYMES",
 		"ZYMIC",
 	}
-
-	// wordsSet is the union of all words in both the wordsCommon and the
-	// wordsRare lists.
-	wordsSet map[string]struct{}
 )
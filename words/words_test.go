@@ -0,0 +1,167 @@
+package words
+
+import (
+	"compress/gzip"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBuiltin(t *testing.T) {
+	for _, l := range Lengths() {
+		d, err := Builtin(l)
+		if err != nil {
+			t.Fatalf("Builtin(%d) error: %s", l, err)
+		}
+		if d.Length() != l {
+			t.Errorf("Builtin(%d) has %d letter words", l, d.Length())
+		}
+		if len(d.Answers()) == 0 {
+			t.Errorf("Builtin(%d) has no answers", l)
+		}
+	}
+	if _, err := Builtin(3); err != ErrUnsupportedLength {
+		t.Errorf("Builtin(3) error = %v, want ErrUnsupportedLength", err)
+	}
+
+	d, _ := Builtin(5)
+	if !d.Contains("CRANE") || d.Contains("CRANX") {
+		t.Errorf("Contains is wrong about CRANE or CRANX")
+	}
+	r := rand.New(rand.NewSource(1))
+	if word := d.Random(r); !d.Contains(word) {
+		t.Errorf("Random picked %s, which isn't a word", word)
+	}
+}
+
+func TestNew(t *testing.T) {
+	d, err := New([]string{"crane", "SLATE", "CRANE"}, []string{"CRANE", "SLATE", "TRACE", "trace"})
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if got, want := d.Answers(), []string{"CRANE", "SLATE"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Answers() = %v, want %v", got, want)
+	}
+	if got, want := d.Guesses(), []string{"CRANE", "SLATE", "TRACE"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Guesses() = %v, want %v", got, want)
+	}
+
+	bad := []struct {
+		name             string
+		answers, guesses []string
+	}{
+		{"no answers", nil, []string{"CRANE"}},
+		{"mixed lengths", []string{"CRANE", "CRANES"}, nil},
+		{"guess length", []string{"CRANE"}, []string{"CRANE", "CRANES"}},
+		{"not letters", []string{"CR4NE"}, nil},
+		{"empty word", []string{"CRANE", ""}, nil},
+		{"answer not a guess", []string{"CRANE"}, []string{"SLATE"}},
+	}
+	for _, tt := range bad {
+		if _, err := New(tt.answers, tt.guesses); err == nil {
+			t.Errorf("%s: should error out, but didn't", tt.name)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	text := write("answers.txt", "# our words\ncrane slate\n\nTRACE # a comment\n")
+	jsonList := write("guesses.json", `["CRANE", "SLATE", "TRACE", "ZZZZZ"]`)
+
+	gzPath := filepath.Join(dir, "answers.txt.gz")
+	f, err := os.Create(gzPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := gzip.NewWriter(f)
+	zw.Write([]byte("CRANE\nSLATE\n"))
+	zw.Close()
+	f.Close()
+
+	d, err := Load(text, jsonList)
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if got, want := d.Answers(), []string{"CRANE", "SLATE", "TRACE"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Answers() = %v, want %v", got, want)
+	}
+	if !d.Contains("ZZZZZ") || d.Contains("AUDIO") {
+		t.Errorf("Guesses should come from the guess list only")
+	}
+
+	d, err = Load(gzPath, "")
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if got, want := d.Answers(), []string{"CRANE", "SLATE"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Answers() = %v, want %v", got, want)
+	}
+	if !d.Contains("AUDIO") {
+		t.Errorf("Without a guess list, the built-in guesses should be allowed")
+	}
+
+	if _, err := Load(write("bad.txt", "CRANE\nCRANES\n"), ""); err == nil {
+		t.Errorf("Should error out for mixed lengths, but didn't")
+	}
+	if _, err := Load(text, write("short.json", `["CRANE"]`)); err == nil {
+		t.Errorf("Should error out for answers that aren't guesses, but didn't")
+	}
+}
+
+func TestDaily(t *testing.T) {
+	morning := time.Date(2023, time.June, 10, 8, 0, 0, 0, time.UTC)
+	evening := time.Date(2023, time.June, 10, 23, 0, 0, 0, time.UTC)
+	d, _ := Builtin(5)
+
+	w1, n1 := d.Daily(morning, "")
+	w2, n2 := d.Daily(evening, "")
+	if w1 != w2 || n1 != n2 {
+		t.Errorf("Same day gave %s #%d and %s #%d", w1, n1, w2, n2)
+	}
+	if n1 != 10 {
+		t.Errorf("Puzzle number = %d, want 10", n1)
+	}
+	if !d.Contains(w1) {
+		t.Errorf("Daily word %s isn't a word", w1)
+	}
+
+	// a salt should pick from the same list, but not always the same word
+	same := 0
+	for i := 0; i < 30; i++ {
+		day := morning.AddDate(0, 0, i)
+		plain, _ := d.Daily(day, "")
+		salted, _ := d.Daily(day, "team")
+		if plain == salted {
+			same++
+		}
+	}
+	if same == 30 {
+		t.Errorf("Salt didn't change any daily words")
+	}
+
+	first, _ := d.Daily(Epoch, "")
+	if w, n := d.Daily(time.Date(2023, time.May, 1, 0, 0, 0, 0, time.UTC), ""); w != first || n != 1 {
+		t.Errorf("Day before the epoch gave %s #%d, want %s #1", w, n, first)
+	}
+}
+
+func TestNilDictionary(t *testing.T) {
+	var d *Dictionary
+	if w := d.Random(rand.New(rand.NewSource(1))); w != "" {
+		t.Errorf("Random = %q, want nothing", w)
+	}
+	if w, n := d.Daily(Epoch, ""); w != "" || n != 0 {
+		t.Errorf("Daily = %q #%d, want nothing", w, n)
+	}
+}
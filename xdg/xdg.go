@@ -0,0 +1,36 @@
+// Package xdg locates godle's files following the XDG base directory
+// specification.
+package xdg
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+const appName = "godle"
+
+// DataFile returns the path of a file named name in godle's data directory,
+// $XDG_DATA_HOME/godle, which defaults to ~/.local/share/godle.
+func DataFile(name string) (string, error) {
+	return file("XDG_DATA_HOME", filepath.Join(".local", "share"), name)
+}
+
+// ConfigFile returns the path of a file named name in godle's config
+// directory, $XDG_CONFIG_HOME/godle, which defaults to ~/.config/godle.
+func ConfigFile(name string) (string, error) {
+	return file("XDG_CONFIG_HOME", ".config", name)
+}
+
+func file(env, fallback, name string) (string, error) {
+	// relative paths are invalid according to the spec and should be ignored
+	dir := os.Getenv(env)
+	if !filepath.IsAbs(dir) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", errors.New("Can't find home directory: " + err.Error())
+		}
+		dir = filepath.Join(home, fallback)
+	}
+	return filepath.Join(dir, appName, name), nil
+}